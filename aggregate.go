@@ -0,0 +1,375 @@
+package arrayfuncs
+
+import (
+	"math"
+	"slices"
+)
+
+type metricKind int
+
+const (
+	metricCount metricKind = iota
+	metricSum
+	metricAvg
+	metricMin
+	metricMax
+)
+
+type metric[T comparable] struct {
+	name  string
+	kind  metricKind
+	field func(v T) float64
+}
+
+// accumulator keep the partial values of one metric of one group
+type accumulator struct {
+	count int
+	sum   float64
+	min   float64
+	max   float64
+}
+
+func (a *accumulator) add(value float64) {
+	if a.count == 0 || value < a.min {
+		a.min = value
+	}
+
+	if a.count == 0 || value > a.max {
+		a.max = value
+	}
+
+	a.count++
+	a.sum += value
+}
+
+func (a *accumulator) result(kind metricKind) float64 {
+	switch kind {
+	case metricCount:
+		return float64(a.count)
+	case metricSum:
+		return a.sum
+	case metricAvg:
+		if a.count == 0 {
+			return math.NaN()
+		}
+
+		return a.sum / float64(a.count)
+	case metricMin:
+		return a.min
+	case metricMax:
+		return a.max
+	}
+
+	return 0
+}
+
+/*
+CompositeKey group by more than one key, it is comparable and can be used as a map key.
+The parts are chained, Rest is another CompositeKey or nil on the last part
+*/
+type CompositeKey struct {
+	First any
+	Rest  any
+}
+
+// NewCompositeKey create a CompositeKey with the parts in order
+func NewCompositeKey(parts ...any) (res CompositeKey) {
+	for i := len(parts) - 1; i >= 0; i-- {
+		if i == len(parts)-1 {
+			res = CompositeKey{First: parts[i]}
+		} else {
+			res = CompositeKey{First: parts[i], Rest: res}
+		}
+	}
+
+	return
+}
+
+// Parts return all the parts of the key in order
+func (k CompositeKey) Parts() (res []any) {
+	var current any = k
+
+	for current != nil {
+		key := current.(CompositeKey)
+		res = append(res, key.First)
+		current = key.Rest
+	}
+
+	return
+}
+
+// AggregateRow is the result of one group of an Aggregation
+type AggregateRow struct {
+	// Key is the group value, or a CompositeKey when grouped by many keys
+	Key   any
+	Count int
+	// Values has the result of each metric, in the order the metrics were added
+	Values []float64
+
+	names []string
+}
+
+// Value return the result of the metric with the name, and false if the metric doesn't exist
+func (r AggregateRow) Value(name string) (value float64, ok bool) {
+	index := slices.Index(r.names, name)
+	if index < 0 {
+		return
+	}
+
+	return r.Values[index], true
+}
+
+/*
+Aggregation group the elements of an Array and calculate metrics of each group
+
+	rows := Aggregate(sales).
+		By(func(s Sale) any { return s.Region }).
+		Count().
+		Sum("total", func(s Sale) float64 { return s.Amount }).
+		Avg("average", func(s Sale) float64 { return s.Amount }).
+		Having(func(row AggregateRow) bool { return row.Count > 1 }).
+		Rows()
+
+	total, _ := rows[0].Value("total")
+
+➡ The rows follow the order that each group first appears in the Array. Use AggregateTo to have the rows as your own type
+*/
+type Aggregation[T comparable] struct {
+	source  Array[T]
+	keys    []func(v T) any
+	metrics []metric[T]
+	having  []func(row AggregateRow) bool
+}
+
+// Aggregate start an Aggregation over the Array
+func Aggregate[T comparable](arr Array[T]) *Aggregation[T] {
+	return &Aggregation[T]{source: arr}
+}
+
+// By set the keys of the groups, when more than one is passed the row Key is a CompositeKey
+func (a *Aggregation[T]) By(keys ...func(v T) any) *Aggregation[T] {
+	a.keys = append(a.keys, keys...)
+
+	return a
+}
+
+// Count add the "count" metric with the elements count of each group
+func (a *Aggregation[T]) Count() *Aggregation[T] {
+	a.metrics = append(a.metrics, metric[T]{name: "count", kind: metricCount, field: func(v T) float64 { return 0 }})
+
+	return a
+}
+
+// Sum add a metric with the sum of the field
+func (a *Aggregation[T]) Sum(name string, field func(v T) float64) *Aggregation[T] {
+	return a.add(name, metricSum, field)
+}
+
+// Avg add a metric with the average of the field
+func (a *Aggregation[T]) Avg(name string, field func(v T) float64) *Aggregation[T] {
+	return a.add(name, metricAvg, field)
+}
+
+// Min add a metric with the minimum value of the field
+func (a *Aggregation[T]) Min(name string, field func(v T) float64) *Aggregation[T] {
+	return a.add(name, metricMin, field)
+}
+
+// Max add a metric with the maximum value of the field
+func (a *Aggregation[T]) Max(name string, field func(v T) float64) *Aggregation[T] {
+	return a.add(name, metricMax, field)
+}
+
+// Having keep only the rows that satisfy the condition
+func (a *Aggregation[T]) Having(condition func(row AggregateRow) bool) *Aggregation[T] {
+	a.having = append(a.having, condition)
+
+	return a
+}
+
+// Rows execute the Aggregation and return one row per group
+func (a *Aggregation[T]) Rows() (res []AggregateRow) {
+	for _, row := range a.group(a.key) {
+		if a.accept(row) {
+			res = append(res, row)
+		}
+	}
+
+	return
+}
+
+/*
+AggregateTo execute the Aggregation and convert each row to the type R
+
+	type RegionTotal struct {
+		Region string
+		Total  float64
+	}
+
+	totals := AggregateTo(Aggregate(sales).By(region).Sum("total", amount), func(row AggregateRow) RegionTotal {
+		return RegionTotal{Region: row.Key.(string), Total: row.Values[0]}
+	})
+*/
+func AggregateTo[T, R comparable](a *Aggregation[T], convert func(row AggregateRow) R) (res Array[R]) {
+	res = Array[R]{}
+
+	for _, row := range a.Rows() {
+		res = append(res, convert(row))
+	}
+
+	return
+}
+
+/*
+PivotTable is a table with the rows keys on the lines, the column keys on the columns and one metric on the cells.
+A cell without elements is NaN
+*/
+type PivotTable struct {
+	RowKeys    []any
+	ColumnKeys []any
+	Cells      [][]float64
+}
+
+// Value return the cell of the row and column keys, and false if they don't exist
+func (p *PivotTable) Value(row, column any) (value float64, ok bool) {
+	for i := range p.RowKeys {
+		if p.RowKeys[i] != row {
+			continue
+		}
+
+		for j := range p.ColumnKeys {
+			if p.ColumnKeys[j] == column {
+				return p.Cells[i][j], true
+			}
+		}
+	}
+
+	return
+}
+
+/*
+Pivot execute the Aggregation splitting each group by the column key, returning the metric with the name in a PivotTable.
+The Having conditions are applied to each cell
+*/
+func (a *Aggregation[T]) Pivot(column func(v T) any, name string) (res PivotTable) {
+	var (
+		rowIndexes    = make(map[any]int)
+		columnIndexes = make(map[any]int)
+	)
+
+	cells := a.group(func(v T) any {
+		return CompositeKey{First: a.key(v), Rest: CompositeKey{First: column(v)}}
+	})
+
+	for _, cell := range cells {
+		var (
+			key         = cell.Key.(CompositeKey)
+			rowKey      = key.First
+			columnKey   = key.Rest.(CompositeKey).First
+			row, hasRow = rowIndexes[rowKey]
+			col, hasCol = columnIndexes[columnKey]
+		)
+
+		if !hasRow {
+			row = len(res.RowKeys)
+			rowIndexes[rowKey] = row
+			res.RowKeys = append(res.RowKeys, rowKey)
+			res.Cells = append(res.Cells, make([]float64, len(res.ColumnKeys)))
+
+			for j := range res.Cells[row] {
+				res.Cells[row][j] = math.NaN()
+			}
+		}
+
+		if !hasCol {
+			col = len(res.ColumnKeys)
+			columnIndexes[columnKey] = col
+			res.ColumnKeys = append(res.ColumnKeys, columnKey)
+
+			for i := range res.Cells {
+				res.Cells[i] = append(res.Cells[i], math.NaN())
+			}
+		}
+
+		if value, ok := cell.Value(name); ok && a.accept(cell) {
+			res.Cells[row][col] = value
+		}
+	}
+
+	return
+}
+
+func (a *Aggregation[T]) add(name string, kind metricKind, field func(v T) float64) *Aggregation[T] {
+	a.metrics = append(a.metrics, metric[T]{name: name, kind: kind, field: field})
+
+	return a
+}
+
+// key return the group of the element, nil when grouped by nothing
+func (a *Aggregation[T]) key(v T) any {
+	switch len(a.keys) {
+	case 0:
+		return nil
+	case 1:
+		return a.keys[0](v)
+	}
+
+	parts := make([]any, len(a.keys))
+	for i := range a.keys {
+		parts[i] = a.keys[i](v)
+	}
+
+	return NewCompositeKey(parts...)
+}
+
+func (a *Aggregation[T]) accept(row AggregateRow) bool {
+	for _, condition := range a.having {
+		if !condition(row) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// group calculate the metrics of each group in the order they appear
+func (a *Aggregation[T]) group(key func(v T) any) (res []AggregateRow) {
+	var (
+		indexes      = make(map[any]int)
+		accumulators [][]accumulator
+	)
+
+	for _, v := range a.source {
+		k := key(v)
+
+		index, ok := indexes[k]
+		if !ok {
+			index = len(res)
+			indexes[k] = index
+			res = append(res, AggregateRow{Key: k})
+			accumulators = append(accumulators, make([]accumulator, len(a.metrics)))
+		}
+
+		res[index].Count++
+
+		for j := range a.metrics {
+			accumulators[index][j].add(a.metrics[j].field(v))
+		}
+	}
+
+	names := make([]string, len(a.metrics))
+	for j, m := range a.metrics {
+		names[j] = m.name
+	}
+
+	for i := range res {
+		res[i].names = names
+		res[i].Values = make([]float64, len(a.metrics))
+
+		for j, m := range a.metrics {
+			res[i].Values[j] = accumulators[i][j].result(m.kind)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,118 @@
+package arrayfuncs_test
+
+import (
+	"math"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type sale struct {
+	region string
+	month  int
+	amount float64
+}
+
+func TestAggregate(t *testing.T) {
+	var (
+		sales = arrayFuncs.Array[sale]{
+			{"north", 1, 10},
+			{"south", 1, 5},
+			{"north", 2, 30},
+			{"east", 2, 7},
+			{"north", 1, 20},
+			{"south", 2, 15},
+		}
+
+		region = func(s sale) any { return s.region }
+		month  = func(s sale) any { return s.month }
+		amount = func(s sale) float64 { return s.amount }
+	)
+
+	t.Run("Metrics", func(t *testing.T) {
+		rows := arrayFuncs.Aggregate(sales).
+			By(region).
+			Count().
+			Sum("total", amount).
+			Avg("average", amount).
+			Min("min", amount).
+			Max("max", amount).
+			Rows()
+
+		assert.Equal(t, 3, len(rows))
+		assert.Equal(t, "north", rows[0].Key)
+		assert.Equal(t, 3, rows[0].Count)
+
+		expected := map[string]float64{"count": 3, "total": 60, "average": 20, "min": 10, "max": 30}
+
+		for name, value := range expected {
+			result, ok := rows[0].Value(name)
+
+			assert.True(t, ok)
+			assert.Equal(t, value, result)
+		}
+
+		_, ok := rows[0].Value("unknown")
+		assert.False(t, ok)
+	})
+
+	t.Run("Having", func(t *testing.T) {
+		rows := arrayFuncs.Aggregate(sales).
+			By(region).
+			Sum("total", amount).
+			Having(func(row arrayFuncs.AggregateRow) bool {
+				total, _ := row.Value("total")
+				return total > 10
+			}).
+			Rows()
+
+		keys := []any{}
+		for _, row := range rows {
+			keys = append(keys, row.Key)
+		}
+
+		assert.Equal(t, []any{"north", "south"}, keys)
+	})
+
+	t.Run("CompositeKey", func(t *testing.T) {
+		rows := arrayFuncs.Aggregate(sales).By(region, month).Sum("total", amount).Rows()
+
+		assert.Equal(t, 5, len(rows))
+		assert.Equal(t, arrayFuncs.NewCompositeKey("north", 1), rows[0].Key)
+		assert.Equal(t, []any{"north", 1}, rows[0].Key.(arrayFuncs.CompositeKey).Parts())
+
+		total, _ := rows[0].Value("total")
+		assert.Equal(t, 30.0, total)
+	})
+
+	t.Run("TypedRows", func(t *testing.T) {
+		type regionTotal struct {
+			region string
+			total  float64
+		}
+
+		aggregation := arrayFuncs.Aggregate(sales).By(region).Count().Sum("total", amount)
+
+		rows := arrayFuncs.AggregateTo(aggregation, func(row arrayFuncs.AggregateRow) regionTotal {
+			return regionTotal{region: row.Key.(string), total: row.Values[1]}
+		})
+
+		assert.Equal(t, arrayFuncs.Array[regionTotal]{{"north", 60}, {"south", 20}, {"east", 7}}, rows)
+		assert.Equal(t, []float64{3, 60}, aggregation.Rows()[0].Values)
+	})
+
+	t.Run("Pivot", func(t *testing.T) {
+		table := arrayFuncs.Aggregate(sales).By(region).Sum("total", amount).Pivot(month, "total")
+
+		assert.Equal(t, []any{"north", "south", "east"}, table.RowKeys)
+		assert.Equal(t, []any{1, 2}, table.ColumnKeys)
+
+		value, ok := table.Value("south", 2)
+		assert.True(t, ok)
+		assert.Equal(t, 15.0, value)
+
+		value, _ = table.Value("east", 1)
+		assert.True(t, math.IsNaN(value))
+	})
+}
@@ -0,0 +1,308 @@
+package arrayfuncs
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+/*
+Array2D is a grid of rows and columns, stored row by row in only one Array.
+
+	grid := NewArray2D[int](2, 3)
+	grid.Set(0, -1, 5)  // the last column of the first row
+	grid.At(-1, 0)      // the first column of the last row
+
+➡ Array[Array[T]] isn't a valid type because an Array isn't comparable, so the rows are converted from and to []Array[T]
+*/
+type Array2D[T comparable] struct {
+	rows  int
+	cols  int
+	cells Array[T]
+}
+
+// NewArray2D create an Array2D with the zero value of T on all the cells
+func NewArray2D[T comparable](rows, cols int) *Array2D[T] {
+	if rows < 0 || cols < 0 {
+		rows, cols = 0, 0
+	}
+
+	return &Array2D[T]{rows: rows, cols: cols, cells: make(Array[T], rows*cols)}
+}
+
+// FromRows create an Array2D with a copy of the rows, all the rows must have the same length
+func FromRows[T comparable](rows []Array[T]) (res *Array2D[T], err error) {
+	if len(rows) == 0 {
+		return NewArray2D[T](0, 0), nil
+	}
+
+	res = NewArray2D[T](len(rows), len(rows[0]))
+
+	for r, row := range rows {
+		if len(row) != res.cols {
+			return nil, fmt.Errorf("%w: row %d has %d columns, expected %d", ErrLengthMismatch, r, len(row), res.cols)
+		}
+
+		copy(res.cells[r*res.cols:], row)
+	}
+
+	return
+}
+
+// Rows return the count of rows
+func (m *Array2D[T]) Rows() int {
+	return m.rows
+}
+
+// Cols return the count of columns
+func (m *Array2D[T]) Cols() int {
+	return m.cols
+}
+
+// normalizeIndex convert a negative index to count from the end, returning false if it is out of the range
+func normalizeIndex(index, length int) (int, bool) {
+	if index < 0 {
+		index += length
+	}
+
+	return index, index >= 0 && index < length
+}
+
+// position return the position of the cell on the storage, or -1 if it doesn't exist
+func (m *Array2D[T]) position(r, c int) int {
+	r, rowOk := normalizeIndex(r, m.rows)
+	c, colOk := normalizeIndex(c, m.cols)
+
+	if !rowOk || !colOk {
+		return -1
+	}
+
+	return r*m.cols + c
+}
+
+// At return the pointer to the cell, or nil if it doesn't exist. Negative indexes count from the end, like Array.At
+func (m *Array2D[T]) At(r, c int) (res *T) {
+	if position := m.position(r, c); position >= 0 {
+		res = &m.cells[position]
+	}
+
+	return
+}
+
+// Set change the value of the cell, return false if it doesn't exist
+func (m *Array2D[T]) Set(r, c int, value T) bool {
+	position := m.position(r, c)
+	if position < 0 {
+		return false
+	}
+
+	m.cells[position] = value
+
+	return true
+}
+
+// Row return the row, or nil if it doesn't exist. The row shares the storage, so changing it changes the Array2D
+func (m *Array2D[T]) Row(r int) (res Array[T]) {
+	r, ok := normalizeIndex(r, m.rows)
+	if !ok {
+		return
+	}
+
+	start, end := r*m.cols, (r+1)*m.cols
+
+	return m.cells[start:end:end]
+}
+
+// Col return a copy of the column, or nil if it doesn't exist
+func (m *Array2D[T]) Col(c int) (res Array[T]) {
+	c, ok := normalizeIndex(c, m.cols)
+	if !ok {
+		return
+	}
+
+	res = make(Array[T], m.rows)
+	for r := range res {
+		res[r] = m.cells[r*m.cols+c]
+	}
+
+	return
+}
+
+// Transpose return a new Array2D where the rows are the columns
+func (m *Array2D[T]) Transpose() (res *Array2D[T]) {
+	res = NewArray2D[T](m.cols, m.rows)
+
+	for r := 0; r < m.rows; r++ {
+		for c := 0; c < m.cols; c++ {
+			res.cells[c*m.rows+r] = m.cells[r*m.cols+c]
+		}
+	}
+
+	return
+}
+
+// MapCells call the callback with each cell, changing the cells like Array.Map
+func (m *Array2D[T]) MapCells(callback func(v *T, r, c int)) {
+	for i := range m.cells {
+		callback(&m.cells[i], i/m.cols, i%m.cols)
+	}
+}
+
+/*
+SliceRect return a copy of the rectangle from the start row and column to the end ones, without the ends.
+Negative indexes count from the end and the indexes out of the range are limited to it
+
+	grid.SliceRect(0, 2, 1, -1) // the first two rows without the first and the last columns
+*/
+func (m *Array2D[T]) SliceRect(rowStart, rowEnd, colStart, colEnd int) (res *Array2D[T]) {
+	rowStart, rowEnd = clampRange(rowStart, rowEnd, m.rows)
+	colStart, colEnd = clampRange(colStart, colEnd, m.cols)
+
+	res = NewArray2D[T](rowEnd-rowStart, colEnd-colStart)
+
+	for r := 0; r < res.rows; r++ {
+		start := (rowStart+r)*m.cols + colStart
+		copy(res.cells[r*res.cols:], m.cells[start:start+res.cols])
+	}
+
+	return
+}
+
+// clampRange convert the negative indexes and limit them to the length
+func clampRange(start, end, length int) (int, int) {
+	clamp := func(index int) int {
+		if index < 0 {
+			index += length
+		}
+
+		if index < 0 {
+			return 0
+		}
+
+		if index > length {
+			return length
+		}
+
+		return index
+	}
+
+	start, end = clamp(start), clamp(end)
+	if end < start {
+		end = start
+	}
+
+	return start, end
+}
+
+// ReduceRows reduce each row to one value, like Array.Reduce, returning one value for row
+func (m *Array2D[T]) ReduceRows(callback func(accumulator any, value T, r, c int) any, initialValue ...any) (res []any) {
+	res = make([]any, m.rows)
+
+	for r := range res {
+		var accumulator any
+		if len(initialValue) > 0 {
+			accumulator = initialValue[0]
+		}
+
+		for c := 0; c < m.cols; c++ {
+			accumulator = callback(accumulator, m.cells[r*m.cols+c], r, c)
+		}
+
+		res[r] = accumulator
+	}
+
+	return
+}
+
+// ReduceCols reduce each column to one value, like Array.Reduce, returning one value for column
+func (m *Array2D[T]) ReduceCols(callback func(accumulator any, value T, r, c int) any, initialValue ...any) (res []any) {
+	res = make([]any, m.cols)
+
+	for c := range res {
+		var accumulator any
+		if len(initialValue) > 0 {
+			accumulator = initialValue[0]
+		}
+
+		for r := 0; r < m.rows; r++ {
+			accumulator = callback(accumulator, m.cells[r*m.cols+c], r, c)
+		}
+
+		res[c] = accumulator
+	}
+
+	return
+}
+
+// ToRows return a copy of the rows
+func (m *Array2D[T]) ToRows() (res []Array[T]) {
+	res = make([]Array[T], m.rows)
+
+	for r := range res {
+		res[r] = make(Array[T], m.cols)
+		copy(res[r], m.Row(r))
+	}
+
+	return
+}
+
+// WriteCSV write the rows as CSV. The cells are converted with AnyToString unless a format function is passed
+func (m *Array2D[T]) WriteCSV(w io.Writer, format ...func(v T) string) error {
+	toString := func(v T) string { return AnyToString(&v) }
+	if len(format) > 0 {
+		toString = format[0]
+	}
+
+	writer := csv.NewWriter(w)
+	record := make([]string, m.cols)
+
+	for r := 0; r < m.rows; r++ {
+		for c, v := range m.Row(r) {
+			record[c] = toString(v)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+/*
+ReadCSV create an Array2D from CSV rows, converting each cell with parse. All the rows must have the same length
+
+	grid, err := ReadCSV(file, strconv.Atoi)
+*/
+func ReadCSV[T comparable](r io.Reader, parse func(cell string) (T, error)) (res *Array2D[T], err error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		if errors.Is(err, csv.ErrFieldCount) {
+			err = fmt.Errorf("%w: %s", ErrLengthMismatch, err)
+		}
+
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return NewArray2D[T](0, 0), nil
+	}
+
+	res = NewArray2D[T](len(records), len(records[0]))
+
+	for r, record := range records {
+		for c, cell := range record {
+			value, parseErr := parse(cell)
+			if parseErr != nil {
+				return nil, fmt.Errorf("arrayfuncs: cell %d,%d: %w", r, c, parseErr)
+			}
+
+			res.cells[r*res.cols+c] = value
+		}
+	}
+
+	return
+}
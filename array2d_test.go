@@ -0,0 +1,163 @@
+package arrayfuncs_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArray2D(t *testing.T) {
+	grid := func() *arrayFuncs.Array2D[int] {
+		res, err := arrayFuncs.FromRows([]arrayFuncs.Array[int]{
+			{1, 2, 3},
+			{4, 5, 6},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return res
+	}
+
+	t.Run("FromRowsAndToRows", func(t *testing.T) {
+		g := grid()
+
+		assert.Equal(t, 2, g.Rows())
+		assert.Equal(t, 3, g.Cols())
+		assert.Equal(t, []arrayFuncs.Array[int]{{1, 2, 3}, {4, 5, 6}}, g.ToRows())
+
+		_, err := arrayFuncs.FromRows([]arrayFuncs.Array[int]{{1, 2}, {3}})
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+
+		empty, err := arrayFuncs.FromRows[int](nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, empty.Rows())
+	})
+
+	t.Run("AtAndSet", func(t *testing.T) {
+		g := grid()
+
+		assert.Equal(t, 2, *g.At(0, 1))
+		assert.Equal(t, 6, *g.At(-1, -1))
+		assert.Equal(t, 4, *g.At(-1, -3))
+		assert.Nil(t, g.At(2, 0))
+		assert.Nil(t, g.At(0, -4))
+
+		assert.True(t, g.Set(-2, -1, 30))
+		assert.False(t, g.Set(5, 0, 1))
+		assert.Equal(t, 30, *g.At(0, 2))
+	})
+
+	t.Run("RowAndCol", func(t *testing.T) {
+		g := grid()
+
+		row := g.Row(-1)
+		assert.Equal(t, arrayFuncs.Array[int]{4, 5, 6}, row)
+
+		row[0] = 40
+		assert.Equal(t, 40, *g.At(1, 0))
+
+		row = append(row, 7)
+		assert.Equal(t, arrayFuncs.Array[int]{1, 2, 3}, g.Row(0))
+
+		col := g.Col(1)
+		assert.Equal(t, arrayFuncs.Array[int]{2, 5}, col)
+
+		col[0] = 20
+		assert.Equal(t, 2, *g.At(0, 1))
+
+		assert.Nil(t, g.Row(2))
+		assert.Nil(t, g.Col(3))
+	})
+
+	t.Run("Transpose", func(t *testing.T) {
+		transposed := grid().Transpose()
+
+		assert.Equal(t, 3, transposed.Rows())
+		assert.Equal(t, []arrayFuncs.Array[int]{{1, 4}, {2, 5}, {3, 6}}, transposed.ToRows())
+	})
+
+	t.Run("MapCells", func(t *testing.T) {
+		g := grid()
+
+		g.MapCells(func(v *int, r, c int) {
+			*v = *v*10 + r + c
+		})
+
+		assert.Equal(t, []arrayFuncs.Array[int]{{10, 21, 32}, {41, 52, 63}}, g.ToRows())
+	})
+
+	t.Run("SliceRect", func(t *testing.T) {
+		g := grid()
+
+		assert.Equal(t, []arrayFuncs.Array[int]{{2}, {5}}, g.SliceRect(0, 2, 1, -1).ToRows())
+		assert.Equal(t, []arrayFuncs.Array[int]{{4, 5, 6}}, g.SliceRect(-1, 10, -10, 10).ToRows())
+		assert.Equal(t, 0, g.SliceRect(1, 0, 0, 3).Rows())
+	})
+
+	t.Run("ReduceRowsAndCols", func(t *testing.T) {
+		g := grid()
+
+		sum := func(accumulator any, v int, _, _ int) any {
+			return accumulator.(int) + v
+		}
+
+		assert.Equal(t, []any{6, 15}, g.ReduceRows(sum, 0))
+		assert.Equal(t, []any{5, 7, 9}, g.ReduceCols(sum, 0))
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		assert.NoError(t, grid().WriteCSV(&buffer))
+		assert.Equal(t, "1,2,3\n4,5,6\n", buffer.String())
+
+		read, err := arrayFuncs.ReadCSV(&buffer, strconv.Atoi)
+		assert.NoError(t, err)
+		assert.Equal(t, grid().ToRows(), read.ToRows())
+
+		_, err = arrayFuncs.ReadCSV(strings.NewReader("1,2\n3\n"), strconv.Atoi)
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+
+		_, err = arrayFuncs.ReadCSV(strings.NewReader("1,x\n"), strconv.Atoi)
+		assert.ErrorContains(t, err, "cell 0,1")
+	})
+
+	t.Run("CSVFormat", func(t *testing.T) {
+		var buffer bytes.Buffer
+
+		names, err := arrayFuncs.FromRows([]arrayFuncs.Array[string]{{"a,b", "c"}})
+		assert.NoError(t, err)
+
+		assert.NoError(t, names.WriteCSV(&buffer, strings.ToUpper))
+		assert.Equal(t, "\"A,B\",C\n", buffer.String())
+	})
+
+	t.Run("CSVNamedTypes", func(t *testing.T) {
+		type (
+			status string
+			price  float64
+			flag   bool
+		)
+
+		var buffer bytes.Buffer
+
+		statuses, err := arrayFuncs.FromRows([]arrayFuncs.Array[status]{{"open", "closed"}})
+		assert.NoError(t, err)
+		assert.NoError(t, statuses.WriteCSV(&buffer))
+
+		prices, err := arrayFuncs.FromRows([]arrayFuncs.Array[price]{{1.5, 20}})
+		assert.NoError(t, err)
+		assert.NoError(t, prices.WriteCSV(&buffer))
+
+		flags, err := arrayFuncs.FromRows([]arrayFuncs.Array[flag]{{true, false}})
+		assert.NoError(t, err)
+		assert.NoError(t, flags.WriteCSV(&buffer))
+
+		assert.Equal(t, "open,closed\n1.5,20\ntrue,false\n", buffer.String())
+	})
+}
@@ -0,0 +1,159 @@
+package arrayfuncs
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBatcherClosed is returned when pushing into a Batcher that was already closed
+var ErrBatcherClosed = errors.New("arrayfuncs: batcher is closed")
+
+// Clock is the time source used by the Batcher, it can be replaced on tests to make them deterministic
+type Clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+/*
+Batcher accumulate the pushed elements in an Array and send them to a handler
+when the Array reaches the size limit or when the interval is elapsed since the first element of the batch.
+
+	b := NewBatcher(100, time.Second, func(batch Array[Event]) {
+		save(batch)
+	})
+
+	b.Push(event1, event2)
+
+	b.Close() // flush the pending elements and wait the handler
+
+➡ The handler is called by only one goroutine, so while it is busy the internal buffer fills up
+and Push blocks until there is space again (back-pressure)
+*/
+type Batcher[T comparable] struct {
+	size     int
+	interval time.Duration
+	handler  func(batch Array[T])
+	clock    Clock
+
+	input chan T
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+/*
+NewBatcher create and start a Batcher.
+
+If size is lower than 1 the batch won't be flushed by size, and if interval is 0 it won't be flushed by time.
+The clock is optional, when not passed the real time is used
+*/
+func NewBatcher[T comparable](size int, interval time.Duration, handler func(batch Array[T]), clock ...Clock) *Batcher[T] {
+	b := &Batcher[T]{
+		size:     size,
+		interval: interval,
+		handler:  handler,
+		clock:    realClock{},
+		done:     make(chan struct{}),
+	}
+
+	if len(clock) > 0 && clock[0] != nil {
+		b.clock = clock[0]
+	}
+
+	bufferSize := size
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	b.input = make(chan T, bufferSize)
+
+	go b.run()
+
+	return b
+}
+
+// Push add one or more elements to the current batch.
+// It is safe to call from many goroutines and blocks while the handler can't keep up
+func (b *Batcher[T]) Push(values ...T) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return ErrBatcherClosed
+	}
+
+	for i := range values {
+		b.input <- values[i]
+	}
+
+	return nil
+}
+
+// Close stop receiving elements, flush the pending ones and wait the handler to finish
+func (b *Batcher[T]) Close() error {
+	b.mu.Lock()
+
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+
+	b.closed = true
+	close(b.input)
+	b.mu.Unlock()
+
+	<-b.done
+
+	return nil
+}
+
+func (b *Batcher[T]) run() {
+	var (
+		batch Array[T]
+		timer <-chan time.Time
+	)
+
+	flush := func() {
+		timer = nil
+
+		if len(batch) == 0 {
+			return
+		}
+
+		current := batch
+		batch = nil
+
+		b.handler(current)
+	}
+
+	defer close(b.done)
+
+	for {
+		select {
+		case v, ok := <-b.input:
+			if !ok {
+				flush()
+				return
+			}
+
+			batch.Push(v)
+
+			if len(batch) == 1 && b.interval > 0 {
+				timer = b.clock.After(b.interval)
+			}
+
+			if b.size > 0 && len(batch) >= b.size {
+				flush()
+			}
+		case <-timer:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package arrayfuncs_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock only fires the timers when Fire is called
+type fakeClock struct {
+	timers chan chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{timers: make(chan chan time.Time, 10)}
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	timer := make(chan time.Time, 1)
+	c.timers <- timer
+
+	return timer
+}
+
+// Fire wait the next timer be created and fire it
+func (c *fakeClock) Fire() {
+	timer := <-c.timers
+	timer <- time.Now()
+}
+
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []arrayFuncs.Array[int]
+	flushed chan struct{}
+}
+
+func newBatchRecorder() *batchRecorder {
+	return &batchRecorder{flushed: make(chan struct{}, 10)}
+}
+
+func (r *batchRecorder) handler(batch arrayFuncs.Array[int]) {
+	r.mu.Lock()
+	r.batches = append(r.batches, batch)
+	r.mu.Unlock()
+
+	r.flushed <- struct{}{}
+}
+
+func (r *batchRecorder) get() []arrayFuncs.Array[int] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.batches
+}
+
+func TestBatcher(t *testing.T) {
+
+	t.Run("FlushBySize", func(t *testing.T) {
+		recorder := newBatchRecorder()
+		b := arrayFuncs.NewBatcher(3, 0, recorder.handler)
+
+		assert.Nil(t, b.Push(1, 2, 3, 4))
+		<-recorder.flushed
+
+		assert.Equal(t, []arrayFuncs.Array[int]{{1, 2, 3}}, recorder.get())
+
+		// Close drains the pending element
+		assert.Nil(t, b.Close())
+		assert.Equal(t, []arrayFuncs.Array[int]{{1, 2, 3}, {4}}, recorder.get())
+	})
+
+	t.Run("FlushByTime", func(t *testing.T) {
+		var (
+			recorder = newBatchRecorder()
+			clock    = newFakeClock()
+			b        = arrayFuncs.NewBatcher(10, time.Minute, recorder.handler, clock)
+		)
+
+		assert.Nil(t, b.Push(1, 2))
+		clock.Fire()
+		<-recorder.flushed
+
+		assert.Equal(t, []arrayFuncs.Array[int]{{1, 2}}, recorder.get())
+
+		assert.Nil(t, b.Push(3))
+		clock.Fire()
+		<-recorder.flushed
+
+		assert.Equal(t, []arrayFuncs.Array[int]{{1, 2}, {3}}, recorder.get())
+
+		assert.Nil(t, b.Close())
+	})
+
+	t.Run("ConcurrentPush", func(t *testing.T) {
+		var (
+			total int
+			mu    sync.Mutex
+			wg    sync.WaitGroup
+		)
+
+		b := arrayFuncs.NewBatcher(7, 0, func(batch arrayFuncs.Array[int]) {
+			mu.Lock()
+			total += len(batch)
+			mu.Unlock()
+		})
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					assert.Nil(t, b.Push(j))
+				}
+			}()
+		}
+
+		wg.Wait()
+		assert.Nil(t, b.Close())
+
+		assert.Equal(t, 1000, total)
+	})
+
+	t.Run("BackPressure", func(t *testing.T) {
+		release := make(chan struct{})
+
+		b := arrayFuncs.NewBatcher(1, 0, func(batch arrayFuncs.Array[int]) {
+			<-release
+		})
+
+		// The first element is taken by the handler and the second fills the buffer
+		assert.Nil(t, b.Push(1, 2))
+
+		pushed := make(chan struct{})
+
+		go func() {
+			assert.Nil(t, b.Push(3))
+			close(pushed)
+		}()
+
+		select {
+		case <-pushed:
+			t.Fatal("push should block while the handler is busy")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		<-pushed
+
+		assert.Nil(t, b.Close())
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		b := arrayFuncs.NewBatcher(2, 0, func(batch arrayFuncs.Array[int]) {})
+
+		assert.Nil(t, b.Close())
+		assert.ErrorIs(t, b.Push(1), arrayFuncs.ErrBatcherClosed)
+		assert.ErrorIs(t, b.Close(), arrayFuncs.ErrBatcherClosed)
+	})
+}
@@ -0,0 +1,276 @@
+package arrayfuncs
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+const wordBits = 64
+
+/*
+BitArray is an Array of booleans that uses only one bit for element, stored on 64 bits words.
+It has the Array methods that make sense for bits, and the bitwise operations
+
+	flags := NewBitArray(100)
+	flags.Set(3, true)
+	flags.Set(70, true)
+
+	flags.PopCount()    // 2
+	flags.NextSetBit(4) // 70
+*/
+type BitArray struct {
+	words  []uint64
+	length int
+}
+
+// NewBitArray create a BitArray with length false elements
+func NewBitArray(length int) *BitArray {
+	if length < 0 {
+		length = 0
+	}
+
+	return &BitArray{words: make([]uint64, (length+wordBits-1)/wordBits), length: length}
+}
+
+// BitArrayFrom create a BitArray with the values of the Array
+func BitArrayFrom(values Array[bool]) *BitArray {
+	res := NewBitArray(len(values))
+
+	for i, v := range values {
+		if v {
+			res.words[i/wordBits] |= 1 << (i % wordBits)
+		}
+	}
+
+	return res
+}
+
+// ToArray return the elements as an Array of booleans
+func (b *BitArray) ToArray() (res Array[bool]) {
+	res = make(Array[bool], b.length)
+
+	for i := range res {
+		res[i] = b.get(i)
+	}
+
+	return
+}
+
+// Len return the count of elements
+func (b *BitArray) Len() int {
+	return b.length
+}
+
+func (b *BitArray) get(index int) bool {
+	return b.words[index/wordBits]&(1<<(index%wordBits)) != 0
+}
+
+func (b *BitArray) set(index int, value bool) {
+	if value {
+		b.words[index/wordBits] |= 1 << (index % wordBits)
+	} else {
+		b.words[index/wordBits] &^= 1 << (index % wordBits)
+	}
+}
+
+// At return a copy of the element, or nil if it doesn't exist. Negative indexes count from the end, like Array.At
+func (b *BitArray) At(index int) (res *bool) {
+	index, ok := normalizeIndex(index, b.length)
+	if !ok {
+		return
+	}
+
+	value := b.get(index)
+
+	return &value
+}
+
+// Set change the element on the index, return false if it doesn't exist
+func (b *BitArray) Set(index int, value bool) bool {
+	index, ok := normalizeIndex(index, b.length)
+	if ok {
+		b.set(index, value)
+	}
+
+	return ok
+}
+
+// Fill set the value from the start index until the end index, like Array.Fill. Whole words are filled at once
+func (b *BitArray) Fill(value bool, start int, end ...int) *BitArray {
+	last := b.length - 1
+	if len(end) > 0 && end[0] < last {
+		last = end[0]
+	}
+
+	i := start
+
+	// Set the bits one by one until a word starts
+	for ; i <= last && i%wordBits != 0; i++ {
+		b.set(i, value)
+	}
+
+	var word uint64
+	if value {
+		word = ^uint64(0)
+	}
+
+	for ; i+wordBits-1 <= last; i += wordBits {
+		b.words[i/wordBits] = word
+	}
+
+	for ; i <= last; i++ {
+		b.set(i, value)
+	}
+
+	return b
+}
+
+// Push add the values on the end
+func (b *BitArray) Push(values ...bool) {
+	for _, v := range values {
+		if b.length%wordBits == 0 {
+			b.words = append(b.words, 0)
+		}
+
+		b.length++
+		b.set(b.length-1, v)
+	}
+}
+
+// Pop remove the last element and return it, or nil if the BitArray is empty
+func (b *BitArray) Pop() (res *bool) {
+	if b.length == 0 {
+		return
+	}
+
+	value := b.get(b.length - 1)
+
+	// Keep the bits after the length as zero
+	b.set(b.length-1, false)
+	b.length--
+	b.words = b.words[:(b.length+wordBits-1)/wordBits]
+
+	return &value
+}
+
+// Every test if all the elements pass the condition callback
+func (b *BitArray) Every(callback func(v bool, i int) bool) bool {
+	for i := 0; i < b.length; i++ {
+		if !callback(b.get(i), i) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Some test if at least one element pass the condition callback
+func (b *BitArray) Some(callback func(v bool, i int) bool) bool {
+	for i := 0; i < b.length; i++ {
+		if callback(b.get(i), i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IndexOf return the first index of the value or -1 if not found, checking whole words at once
+func (b *BitArray) IndexOf(value bool) int {
+	for w, word := range b.words {
+		if !value {
+			word = ^word
+		}
+
+		if word != 0 {
+			index := w*wordBits + bits.TrailingZeros64(word)
+			if index < b.length {
+				return index
+			}
+		}
+	}
+
+	return -1
+}
+
+// NextSetBit return the first index from the 'from' index that is true, or -1 if there isn't one
+func (b *BitArray) NextSetBit(from int) int {
+	if from < 0 {
+		from = 0
+	}
+
+	if from >= b.length {
+		return -1
+	}
+
+	w := from / wordBits
+	word := b.words[w] &^ (1<<(from%wordBits) - 1)
+
+	for {
+		if word != 0 {
+			return w*wordBits + bits.TrailingZeros64(word)
+		}
+
+		w++
+		if w == len(b.words) {
+			return -1
+		}
+
+		word = b.words[w]
+	}
+}
+
+// PopCount return the count of true elements
+func (b *BitArray) PopCount() (count int) {
+	for _, word := range b.words {
+		count += bits.OnesCount64(word)
+	}
+
+	return
+}
+
+// bitwise apply the operation on each pair of words, both BitArrays must have the same length
+func (b *BitArray) bitwise(other *BitArray, op func(x, y uint64) uint64) (res *BitArray, err error) {
+	if b.length != other.length {
+		return nil, fmt.Errorf("%w: %d and %d", ErrLengthMismatch, b.length, other.length)
+	}
+
+	res = NewBitArray(b.length)
+
+	for i := range b.words {
+		res.words[i] = op(b.words[i], other.words[i])
+	}
+
+	return
+}
+
+// And return a BitArray where each element is true if both elements are true
+func (b *BitArray) And(other *BitArray) (res *BitArray, err error) {
+	return b.bitwise(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or return a BitArray where each element is true if any of the elements is true
+func (b *BitArray) Or(other *BitArray) (res *BitArray, err error) {
+	return b.bitwise(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor return a BitArray where each element is true if only one of the elements is true
+func (b *BitArray) Xor(other *BitArray) (res *BitArray, err error) {
+	return b.bitwise(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// Not return a BitArray with all the elements inverted
+func (b *BitArray) Not() (res *BitArray) {
+	res = NewBitArray(b.length)
+
+	for i, word := range b.words {
+		res.words[i] = ^word
+	}
+
+	// Keep the bits after the length as zero
+	if tail := b.length % wordBits; tail != 0 {
+		res.words[len(res.words)-1] &= 1<<tail - 1
+	}
+
+	return
+}
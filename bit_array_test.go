@@ -0,0 +1,147 @@
+package arrayfuncs_test
+
+import (
+	"math/rand"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitArray(t *testing.T) {
+	randomBools := func(size int, seed int64) (res arrayFuncs.Array[bool]) {
+		random := rand.New(rand.NewSource(seed))
+
+		res = make(arrayFuncs.Array[bool], size)
+		for i := range res {
+			res[i] = random.Intn(3) == 0
+		}
+
+		return
+	}
+
+	t.Run("Convert", func(t *testing.T) {
+		values := randomBools(200, 1)
+
+		b := arrayFuncs.BitArrayFrom(values)
+
+		assert.Equal(t, 200, b.Len())
+		assert.Equal(t, values, b.ToArray())
+	})
+
+	t.Run("AtAndSet", func(t *testing.T) {
+		b := arrayFuncs.NewBitArray(100)
+
+		assert.True(t, b.Set(3, true))
+		assert.True(t, b.Set(-1, true))
+		assert.False(t, b.Set(100, true))
+
+		assert.True(t, *b.At(3))
+		assert.True(t, *b.At(99))
+		assert.False(t, *b.At(4))
+		assert.Nil(t, b.At(100))
+		assert.Nil(t, b.At(-101))
+	})
+
+	t.Run("Fill", func(t *testing.T) {
+		b := arrayFuncs.NewBitArray(200)
+		b.Fill(true, 10, 150)
+
+		assert.Equal(t, 141, b.PopCount())
+		assert.Equal(t, 10, b.IndexOf(true))
+		assert.False(t, *b.At(151))
+
+		b.Fill(false, 0)
+		assert.Equal(t, 0, b.PopCount())
+
+		b.Fill(true, 64)
+		assert.Equal(t, 136, b.PopCount())
+	})
+
+	t.Run("PushAndPop", func(t *testing.T) {
+		b := arrayFuncs.NewBitArray(0)
+
+		assert.Nil(t, b.Pop())
+
+		for i := 0; i < 130; i++ {
+			b.Push(i%2 == 0)
+		}
+
+		b.Push(true, false)
+		assert.Equal(t, 132, b.Len())
+
+		assert.False(t, *b.Pop())
+		assert.True(t, *b.Pop())
+		assert.False(t, *b.Pop())
+		assert.Equal(t, 129, b.Len())
+		assert.Equal(t, 65, b.PopCount())
+
+		b.Push(false)
+		assert.False(t, *b.At(-1))
+	})
+
+	t.Run("EverySomeIndexOf", func(t *testing.T) {
+		b := arrayFuncs.BitArrayFrom(arrayFuncs.Array[bool]{true, true, false})
+
+		isTrue := func(v bool, _ int) bool { return v }
+
+		assert.False(t, b.Every(isTrue))
+		assert.True(t, b.Some(func(v bool, _ int) bool { return !v }))
+		assert.Equal(t, 2, b.IndexOf(false))
+
+		b.Set(2, true)
+		assert.True(t, b.Every(isTrue))
+		assert.Equal(t, -1, b.IndexOf(false))
+		assert.Equal(t, -1, arrayFuncs.NewBitArray(70).IndexOf(true))
+	})
+
+	t.Run("NextSetBit", func(t *testing.T) {
+		b := arrayFuncs.NewBitArray(300)
+		b.Set(5, true)
+		b.Set(64, true)
+		b.Set(299, true)
+
+		var found []int
+		for i := b.NextSetBit(0); i >= 0; i = b.NextSetBit(i + 1) {
+			found = append(found, i)
+		}
+
+		assert.Equal(t, []int{5, 64, 299}, found)
+		assert.Equal(t, -1, b.NextSetBit(300))
+	})
+
+	t.Run("Bitwise", func(t *testing.T) {
+		x, y := randomBools(150, 2), randomBools(150, 3)
+		a, b := arrayFuncs.BitArrayFrom(x), arrayFuncs.BitArrayFrom(y)
+
+		and, err := a.And(b)
+		assert.NoError(t, err)
+
+		or, err := a.Or(b)
+		assert.NoError(t, err)
+
+		xor, err := a.Xor(b)
+		assert.NoError(t, err)
+
+		not := a.Not()
+
+		count := 0
+
+		for i := range x {
+			assert.Equal(t, x[i] && y[i], *and.At(i))
+			assert.Equal(t, x[i] || y[i], *or.At(i))
+			assert.Equal(t, x[i] != y[i], *xor.At(i))
+			assert.Equal(t, !x[i], *not.At(i))
+
+			if x[i] {
+				count++
+			}
+		}
+
+		assert.Equal(t, count, a.PopCount())
+		assert.Equal(t, 150-count, not.PopCount())
+
+		_, err = a.And(arrayFuncs.NewBitArray(10))
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+	})
+}
@@ -0,0 +1,176 @@
+package arrayfuncs
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+/*
+Collator compare strings in a human way. All the options can be combined
+
+	Natural        the digits are compared as numbers, so "file2" goes before "file10"
+	IgnoreCase     "a" and "A" are equivalent
+	IgnoreAccents  "é" and "e" are equivalent, the strings are decomposed with Unicode NFD and the combining marks
+	               are removed, so it works on any script. The latin letters without decomposition, like "ø" and "ł", are folded too
+*/
+type Collator struct {
+	Natural       bool
+	IgnoreCase    bool
+	IgnoreAccents bool
+}
+
+/*
+SortDefault sorts the Array like the JavaScript sort without comparator:
+the elements are converted with AnyToString and compared by their UTF-16 code units.
+If a Collator is passed the strings are compared by it instead
+
+	a := Array[int]{10, 9, 1}
+	a.SortDefault() // 'a' now is {1, 10, 9}
+
+	b := Array[string]{"file10", "File2"}
+	b.SortDefault(Collator{Natural: true, IgnoreCase: true}) // 'b' now is {"File2", "file10"}
+*/
+func (l *Array[T]) SortDefault(collator ...Collator) {
+	keys := make([]string, len(*l))
+
+	for i := range *l {
+		keys[i] = AnyToString(&(*l)[i])
+	}
+
+	compare := compareUTF16
+	if len(collator) > 0 {
+		compare = collator[0].Compare
+	}
+
+	indexes := l.Keys()
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return compare(keys[indexes[i]], keys[indexes[j]]) < 0
+	})
+
+	sorted := make(Array[T], len(*l))
+	for i, index := range indexes {
+		sorted[i] = (*l)[index]
+	}
+
+	*l = sorted
+}
+
+// compareUTF16 compare the strings by their UTF-16 code units, like JavaScript does
+func compareUTF16(a, b string) int {
+	var (
+		x = utf16.Encode([]rune(a))
+		y = utf16.Encode([]rune(b))
+	)
+
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] != y[i] {
+			return compareOrderedValues(x[i], y[i])
+		}
+	}
+
+	return compareOrderedValues(len(x), len(y))
+}
+
+// Compare return a negative number when a goes before b, a positive one when a goes after b and 0 if they are equivalent
+func (c Collator) Compare(a, b string) int {
+	a, b = c.fold(a), c.fold(b)
+
+	if !c.Natural {
+		return compareOrderedValues(a, b)
+	}
+
+	x, y := []rune(a), []rune(b)
+
+	for len(x) > 0 && len(y) > 0 {
+		if unicode.IsDigit(x[0]) && unicode.IsDigit(y[0]) {
+			var numberX, numberY []rune
+
+			numberX, x = splitDigits(x)
+			numberY, y = splitDigits(y)
+
+			if result := compareNumbers(numberX, numberY); result != 0 {
+				return result
+			}
+
+			continue
+		}
+
+		if x[0] != y[0] {
+			return compareOrderedValues(x[0], y[0])
+		}
+
+		x, y = x[1:], y[1:]
+	}
+
+	return compareOrderedValues(len(x), len(y))
+}
+
+// fold apply the case and accent options on the string
+func (c Collator) fold(s string) string {
+	if c.IgnoreAccents {
+		s = removeAccents(s)
+	}
+
+	if c.IgnoreCase {
+		s = strings.ToLower(s)
+	}
+
+	return s
+}
+
+func splitDigits(s []rune) (digits, rest []rune) {
+	i := 0
+	for i < len(s) && unicode.IsDigit(s[i]) {
+		i++
+	}
+
+	return s[:i], s[i:]
+}
+
+// compareNumbers compare two sequences of digits by their value, with less leading zeros going first on a tie
+func compareNumbers(a, b []rune) int {
+	trimmedA := strings.TrimLeft(string(a), "0")
+	trimmedB := strings.TrimLeft(string(b), "0")
+
+	if result := compareOrderedValues(len(trimmedA), len(trimmedB)); result != 0 {
+		return result
+	}
+
+	if result := compareOrderedValues(trimmedA, trimmedB); result != 0 {
+		return result
+	}
+
+	return compareOrderedValues(len(a), len(b))
+}
+
+// foldings map the latin letters that have no Unicode decomposition, like the letters with a stroke, to their base letters
+var foldings = map[rune]string{ //nolint:gochecknoglobals
+	'đ': "d", 'Đ': "D", 'ħ': "h", 'Ħ': "H", 'ı': "i", 'ł': "l", 'Ł': "L",
+	'ø': "o", 'Ø': "O", 'ŧ': "t", 'Ŧ': "T",
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+}
+
+// removeAccents apply the canonical decomposition (NFD) and remove the combining marks, then replace the foldings
+func removeAccents(s string) string {
+	var builder strings.Builder
+
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+
+		if base, ok := foldings[r]; ok {
+			builder.WriteString(base)
+			continue
+		}
+
+		builder.WriteRune(r)
+	}
+
+	return builder.String()
+}
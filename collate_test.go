@@ -0,0 +1,82 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortDefault(t *testing.T) {
+
+	t.Run("JavaScriptOrder", func(t *testing.T) {
+		numbers := arrayFuncs.Array[int]{10, 9, 1, 100, 25}
+		numbers.SortDefault()
+
+		assert.Equal(t, arrayFuncs.Array[int]{1, 10, 100, 25, 9}, numbers)
+
+		wide := arrayFuncs.Array[int64]{3, 1, 2, 10}
+		wide.SortDefault()
+
+		assert.Equal(t, arrayFuncs.Array[int64]{1, 10, 2, 3}, wide)
+
+		unsigned := arrayFuncs.Array[uint16]{9, 80, 700}
+		unsigned.SortDefault()
+
+		assert.Equal(t, arrayFuncs.Array[uint16]{700, 80, 9}, unsigned)
+
+		// "｡" is greater than "😀" in UTF-16 but lower in code points
+		texts := arrayFuncs.Array[string]{"｡", "😀", "b", "B", "a"}
+		texts.SortDefault()
+
+		assert.Equal(t, arrayFuncs.Array[string]{"B", "a", "b", "😀", "｡"}, texts)
+	})
+
+	t.Run("NamedTypes", func(t *testing.T) {
+		type status string
+
+		type price float32
+
+		statuses := arrayFuncs.Array[status]{"open", "closed", "Draft"}
+		statuses.SortDefault()
+
+		assert.Equal(t, arrayFuncs.Array[status]{"Draft", "closed", "open"}, statuses)
+
+		prices := arrayFuncs.Array[price]{9.5, 10.25, -1}
+		prices.SortDefault()
+
+		assert.Equal(t, arrayFuncs.Array[price]{-1, 10.25, 9.5}, prices)
+	})
+
+	t.Run("Structs", func(t *testing.T) {
+		list := arrayFuncs.Array[Temp]{{"world", 1}, {"hello", 2}}
+		list.SortDefault()
+
+		assert.Equal(t, "hello", list[0].ToString())
+	})
+
+	t.Run("Collator", func(t *testing.T) {
+		files := arrayFuncs.Array[string]{"file10", "File2", "file1", "file02", "éclair", "eclair", "Zeta"}
+
+		files.SortDefault(arrayFuncs.Collator{Natural: true, IgnoreCase: true, IgnoreAccents: true})
+
+		assert.Equal(t, arrayFuncs.Array[string]{"éclair", "eclair", "file1", "File2", "file02", "file10", "Zeta"}, files)
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		natural := arrayFuncs.Collator{Natural: true}
+
+		assert.Equal(t, -1, natural.Compare("file2", "file10"))
+		assert.Equal(t, 1, natural.Compare("file2", "File10"))
+		assert.Equal(t, 0, arrayFuncs.Collator{IgnoreCase: true}.Compare("ABC", "abc"))
+		assert.Equal(t, 0, arrayFuncs.Collator{IgnoreAccents: true}.Compare("Ångström", "Angstrom"))
+
+		// Decomposed accents are removed too
+		assert.Equal(t, 0, arrayFuncs.Collator{IgnoreAccents: true}.Compare("e\u0301", "e"))
+
+		// Any script is decomposed, and the letters without decomposition are folded
+		assert.Equal(t, 0, arrayFuncs.Collator{IgnoreAccents: true}.Compare("ǎṡẞ", "asẞ"))
+		assert.Equal(t, 0, arrayFuncs.Collator{IgnoreAccents: true}.Compare("Ελληνικά", "Ελληνικα"))
+		assert.Equal(t, 0, arrayFuncs.Collator{IgnoreAccents: true}.Compare("Łódź Øre", "Lodz Ore"))
+	})
+}
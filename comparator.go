@@ -0,0 +1,175 @@
+package arrayfuncs
+
+import (
+	"sort"
+	"strings"
+)
+
+// Ordered is the constraint of the types that can be compared with < and >
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+/*
+Comparator compare two elements, returning a negative number when a goes before b,
+a positive number when a goes after b and 0 when they are equivalent.
+Unlike the Sort callback it doesn't depend on the indexes, so it can be reused by many Arrays
+
+	byAge := Ascending(func(u User) int { return u.Age })
+	byName := CaseInsensitive(func(u User) string { return u.Name })
+
+	users.SortFunc(byAge.Reverse().ThenBy(byName))
+*/
+type Comparator[T comparable] func(a, b T) int
+
+// Direction is the direction of a key on OrderBy
+type Direction int
+
+const (
+	// Asc sorts from the lowest to the greatest value
+	Asc Direction = iota
+	// Desc sorts from the greatest to the lowest value
+	Desc
+)
+
+// SortStability choose if the equivalent elements keep their order when sorting
+type SortStability int
+
+const (
+	// Stable keeps the order of the equivalent elements
+	Stable SortStability = iota
+	// Unstable can change the order of the equivalent elements, but it's faster
+	Unstable
+)
+
+// OrderKey is one key of OrderBy
+type OrderKey[T comparable] struct {
+	Comparator Comparator[T]
+	Direction  Direction
+}
+
+func compareOrderedValues[K Ordered](a, b K) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+
+	return 0
+}
+
+// Ascending return a Comparator that sorts by the key from the lowest to the greatest
+func Ascending[T comparable, K Ordered](key func(v T) K) Comparator[T] {
+	return func(a, b T) int {
+		return compareOrderedValues(key(a), key(b))
+	}
+}
+
+// Descending return a Comparator that sorts by the key from the greatest to the lowest
+func Descending[T comparable, K Ordered](key func(v T) K) Comparator[T] {
+	return Ascending(key).Reverse()
+}
+
+// CaseInsensitive return a Comparator that sorts by the string key ignoring the letters case
+func CaseInsensitive[T comparable](key func(v T) string) Comparator[T] {
+	return func(a, b T) int {
+		return compareOrderedValues(strings.ToLower(key(a)), strings.ToLower(key(b)))
+	}
+}
+
+// Key create an OrderKey that sorts by the key on the direction
+func Key[T comparable, K Ordered](key func(v T) K, direction Direction) OrderKey[T] {
+	return OrderKey[T]{Comparator: Ascending(key), Direction: direction}
+}
+
+// ThenBy return a Comparator that uses the next one when the elements are equivalent
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if result := c(a, b); result != 0 {
+			return result
+		}
+
+		return next(a, b)
+	}
+}
+
+// Reverse return a Comparator with the inverse order
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// NullsFirst return a Comparator that puts the null elements, according to isNull, before the others
+func (c Comparator[T]) NullsFirst(isNull func(v T) bool) Comparator[T] {
+	return c.nulls(isNull, -1)
+}
+
+// NullsLast return a Comparator that puts the null elements, according to isNull, after the others
+func (c Comparator[T]) NullsLast(isNull func(v T) bool) Comparator[T] {
+	return c.nulls(isNull, 1)
+}
+
+func (c Comparator[T]) nulls(isNull func(v T) bool, position int) Comparator[T] {
+	return func(a, b T) int {
+		aNull, bNull := isNull(a), isNull(b)
+
+		switch {
+		case aNull && bNull:
+			return 0
+		case aNull:
+			return position
+		case bNull:
+			return -position
+		}
+
+		return c(a, b)
+	}
+}
+
+// SortFunc sorts the Array with the Comparator, it is stable unless Unstable is passed
+func (l *Array[T]) SortFunc(cmp Comparator[T], stability ...SortStability) {
+	less := func(i, j int) bool {
+		return cmp((*l)[i], (*l)[j]) < 0
+	}
+
+	if len(stability) > 0 && stability[0] == Unstable {
+		sort.Slice(*l, less)
+		return
+	}
+
+	sort.SliceStable(*l, less)
+}
+
+/*
+OrderBy sorts the Array by many keys, each one with its direction. The sort is stable
+
+	users.OrderBy(
+		Key(func(u User) string { return u.Team }, Asc),
+		Key(func(u User) int { return u.Age }, Desc),
+	)
+*/
+func (l *Array[T]) OrderBy(keys ...OrderKey[T]) {
+	if len(keys) == 0 {
+		return
+	}
+
+	var cmp Comparator[T]
+
+	for _, key := range keys {
+		next := key.Comparator
+		if key.Direction == Desc {
+			next = next.Reverse()
+		}
+
+		if cmp == nil {
+			cmp = next
+		} else {
+			cmp = cmp.ThenBy(next)
+		}
+	}
+
+	l.SortFunc(cmp)
+}
@@ -0,0 +1,99 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type player struct {
+	name  string
+	team  string
+	score *int
+}
+
+func TestComparator(t *testing.T) {
+	var (
+		one   = 1
+		two   = 2
+		three = 3
+	)
+
+	players := func() arrayFuncs.Array[player] {
+		return arrayFuncs.Array[player]{
+			{"carl", "red", &two},
+			{"Ann", "blue", &three},
+			{"bob", "red", nil},
+			{"dan", "blue", &one},
+			{"Eve", "red", &two},
+		}
+	}
+
+	namesOf := func(list arrayFuncs.Array[player]) (res []string) {
+		for _, p := range list {
+			res = append(res, p.name)
+		}
+
+		return
+	}
+
+	var (
+		byTeam  = arrayFuncs.Ascending(func(p player) string { return p.team })
+		byName  = arrayFuncs.CaseInsensitive(func(p player) string { return p.name })
+		noScore = func(p player) bool { return p.score == nil }
+		byScore = arrayFuncs.Ascending(func(p player) int {
+			if p.score == nil {
+				return 0
+			}
+
+			return *p.score
+		})
+	)
+
+	t.Run("ThenBy", func(t *testing.T) {
+		list := players()
+		list.SortFunc(byTeam.ThenBy(byName))
+
+		assert.Equal(t, []string{"Ann", "dan", "bob", "carl", "Eve"}, namesOf(list))
+	})
+
+	t.Run("DescendingAndReverse", func(t *testing.T) {
+		list := players()
+		list.SortFunc(arrayFuncs.Descending(func(p player) string { return p.name }))
+
+		assert.Equal(t, []string{"dan", "carl", "bob", "Eve", "Ann"}, namesOf(list))
+
+		list.SortFunc(byName.Reverse())
+
+		assert.Equal(t, []string{"Eve", "dan", "carl", "bob", "Ann"}, namesOf(list))
+	})
+
+	t.Run("Nulls", func(t *testing.T) {
+		list := players()
+		list.SortFunc(byScore.NullsLast(noScore))
+
+		assert.Equal(t, []string{"dan", "carl", "Eve", "Ann", "bob"}, namesOf(list))
+
+		list.SortFunc(byScore.Reverse().NullsFirst(noScore))
+
+		assert.Equal(t, []string{"bob", "Ann", "carl", "Eve", "dan"}, namesOf(list))
+	})
+
+	t.Run("Unstable", func(t *testing.T) {
+		list := players()
+		list.SortFunc(byName, arrayFuncs.Unstable)
+
+		assert.Equal(t, []string{"Ann", "bob", "carl", "dan", "Eve"}, namesOf(list))
+	})
+
+	t.Run("OrderBy", func(t *testing.T) {
+		list := players()
+		list.OrderBy(
+			arrayFuncs.Key(func(p player) string { return p.team }, arrayFuncs.Desc),
+			arrayFuncs.OrderKey[player]{Comparator: byScore, Direction: arrayFuncs.Desc},
+		)
+
+		assert.Equal(t, []string{"carl", "Eve", "bob", "Ann", "dan"}, namesOf(list))
+	})
+}
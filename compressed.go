@@ -0,0 +1,375 @@
+package arrayfuncs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+)
+
+const (
+	// compressedBlockSize is the count of values on each block, the first one is on the skip index
+	compressedBlockSize = 128
+	// compressedVersion is the version of the binary format written by MarshalBinary
+	compressedVersion = 1
+	compressedMagic   = "AFCI"
+)
+
+var (
+	// ErrInvalidFormat is returned when the binary data can't be decoded
+	ErrInvalidFormat = errors.New("arrayfuncs: invalid binary format")
+	// ErrUnsupportedVersion is returned when the binary data has a version that can't be decoded
+	ErrUnsupportedVersion = errors.New("arrayfuncs: unsupported format version")
+)
+
+/*
+CompressedIntArray keep int64 values in blocks of 128, where each value is stored as the zigzag varint of its
+difference to the previous one. Sorted or close values use one or two bytes each.
+The first value of each block is kept on a skip index, so At decodes only one block and Includes does a binary search
+
+	ids := NewCompressedIntArray(1000, 1003, 1004, 1010)
+	ids.Append(1011)
+	ids.Includes(1004) // true
+
+	data, _ := ids.MarshalBinary()
+*/
+type CompressedIntArray struct {
+	data     []byte
+	heads    []int64
+	offsets  []int
+	length   int
+	last     int64
+	unsorted bool
+}
+
+// NewCompressedIntArray create a CompressedIntArray with the values
+func NewCompressedIntArray(values ...int64) *CompressedIntArray {
+	res := &CompressedIntArray{}
+	res.Append(values...)
+
+	return res
+}
+
+// Len return the count of values
+func (c *CompressedIntArray) Len() int {
+	return c.length
+}
+
+// Append add the values on the end
+func (c *CompressedIntArray) Append(values ...int64) {
+	for _, v := range values {
+		if c.length > 0 && v < c.last {
+			c.unsorted = true
+		}
+
+		if c.length%compressedBlockSize == 0 {
+			c.heads = append(c.heads, v)
+			c.offsets = append(c.offsets, len(c.data))
+		} else {
+			// The difference can overflow, but it wraps back when decoding
+			c.data = binary.AppendVarint(c.data, v-c.last)
+		}
+
+		c.last = v
+		c.length++
+	}
+}
+
+// blockEnd return where the block ends on the data
+func (c *CompressedIntArray) blockEnd(block int) int {
+	if block+1 < len(c.offsets) {
+		return c.offsets[block+1]
+	}
+
+	return len(c.data)
+}
+
+// blockValues yield the values of the block while yield return true
+func (c *CompressedIntArray) blockValues(block int, yield func(v int64) bool) {
+	var (
+		value = c.heads[block]
+		data  = c.data[c.offsets[block]:c.blockEnd(block)]
+	)
+
+	if !yield(value) {
+		return
+	}
+
+	for len(data) > 0 {
+		delta, n := binary.Varint(data)
+		data = data[n:]
+		value += delta
+
+		if !yield(value) {
+			return
+		}
+	}
+}
+
+// At return a copy of the value on the index, or nil if it doesn't exist. Negative indexes count from the end, like Array.At
+func (c *CompressedIntArray) At(index int) (res *int64) {
+	index, ok := normalizeIndex(index, c.length)
+	if !ok {
+		return
+	}
+
+	position := index % compressedBlockSize
+
+	c.blockValues(index/compressedBlockSize, func(v int64) bool {
+		if position == 0 {
+			res = &v
+			return false
+		}
+
+		position--
+
+		return true
+	})
+
+	return
+}
+
+/*
+All return an iterator over the indexes and the values, decoding one block at a time
+
+	for i, id := range ids.All() {
+		...
+	}
+*/
+func (c *CompressedIntArray) All() iter.Seq2[int, int64] {
+	return func(yield func(int, int64) bool) {
+		index := 0
+
+		for block := range c.heads {
+			stopped := false
+
+			c.blockValues(block, func(v int64) bool {
+				if !yield(index, v) {
+					stopped = true
+					return false
+				}
+
+				index++
+
+				return true
+			})
+
+			if stopped {
+				return
+			}
+		}
+	}
+}
+
+// ToArray return all the values decoded
+func (c *CompressedIntArray) ToArray() (res Array[int64]) {
+	res = make(Array[int64], 0, c.length)
+
+	for _, v := range c.All() {
+		res = append(res, v)
+	}
+
+	return
+}
+
+// Includes test if the value is on the Array. When the values are sorted only one block is decoded
+func (c *CompressedIntArray) Includes(value int64) (found bool) {
+	check := func(v int64) bool {
+		found = v == value
+		return !found
+	}
+
+	if c.unsorted {
+		for block := range c.heads {
+			if c.blockValues(block, check); found {
+				return
+			}
+		}
+
+		return
+	}
+
+	// The last block with head lower or equal to the value is the only one that can have it
+	block := sort.Search(len(c.heads), func(i int) bool { return c.heads[i] > value }) - 1
+	if block < 0 {
+		return
+	}
+
+	c.blockValues(block, func(v int64) bool {
+		return check(v) && v < value
+	})
+
+	return
+}
+
+/*
+MarshalBinary encode the CompressedIntArray. The format is
+
+	"AFCI" version(1 byte) length(uvarint) blocks(uvarint)
+	for each block: head(varint, difference to the previous head) size(uvarint)
+	the data of all the blocks
+*/
+func (c *CompressedIntArray) MarshalBinary() (data []byte, err error) {
+	data = append(data, compressedMagic...)
+	data = append(data, compressedVersion)
+	data = binary.AppendUvarint(data, uint64(c.length))
+	data = binary.AppendUvarint(data, uint64(len(c.heads)))
+
+	var previous int64
+
+	for block, head := range c.heads {
+		data = binary.AppendVarint(data, head-previous)
+		data = binary.AppendUvarint(data, uint64(c.blockEnd(block)-c.offsets[block]))
+		previous = head
+	}
+
+	return append(data, c.data...), nil
+}
+
+// UnmarshalBinary decode the data written by MarshalBinary, replacing the values
+func (c *CompressedIntArray) UnmarshalBinary(data []byte) (err error) {
+	if len(data) < len(compressedMagic)+1 || string(data[:len(compressedMagic)]) != compressedMagic {
+		return fmt.Errorf("%w: missing header", ErrInvalidFormat)
+	}
+
+	if version := data[len(compressedMagic)]; version != compressedVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	reader := byteReader{data: data[len(compressedMagic)+1:]}
+
+	var (
+		length = reader.uvarint()
+		blocks = reader.uvarint()
+	)
+
+	// Each value uses at least one byte, so a bigger length is invalid
+	if reader.err != nil || length > uint64(len(data)) || blocks != (length+compressedBlockSize-1)/compressedBlockSize {
+		return fmt.Errorf("%w: invalid length", ErrInvalidFormat)
+	}
+
+	res := CompressedIntArray{
+		heads:   make([]int64, blocks),
+		offsets: make([]int, blocks),
+		length:  int(length),
+	}
+
+	var head int64
+
+	sizes := make([]int, blocks)
+
+	for block := range res.heads {
+		head += reader.varint()
+		res.heads[block] = head
+
+		size := reader.uvarint()
+		if size > uint64(len(data)) {
+			return fmt.Errorf("%w: invalid block size", ErrInvalidFormat)
+		}
+
+		sizes[block] = int(size)
+	}
+
+	if reader.err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidFormat, reader.err)
+	}
+
+	res.data = append([]byte{}, reader.data...)
+
+	offset := 0
+	for block, size := range sizes {
+		res.offsets[block] = offset
+		offset += size
+	}
+
+	if offset != len(res.data) {
+		return fmt.Errorf("%w: block sizes don't match the data", ErrInvalidFormat)
+	}
+
+	if err = res.validate(); err != nil {
+		return
+	}
+
+	*c = res
+
+	return
+}
+
+// validate decode all the blocks, checking the count of values and finding the last value and the order
+func (c *CompressedIntArray) validate() error {
+	count := 0
+
+	for block := range c.heads {
+		data := c.data[c.offsets[block]:c.blockEnd(block)]
+		value := c.heads[block]
+
+		if block > 0 && value < c.last {
+			c.unsorted = true
+		}
+
+		c.last = value
+		count++
+
+		for len(data) > 0 {
+			delta, n := binary.Varint(data)
+			if n <= 0 {
+				return fmt.Errorf("%w: invalid varint on block %d", ErrInvalidFormat, block)
+			}
+
+			data = data[n:]
+			value += delta
+
+			if value < c.last {
+				c.unsorted = true
+			}
+
+			c.last = value
+			count++
+		}
+
+		if expected := min(compressedBlockSize, c.length-block*compressedBlockSize); count-block*compressedBlockSize != expected {
+			return fmt.Errorf("%w: block %d has the wrong count of values", ErrInvalidFormat, block)
+		}
+	}
+
+	return nil
+}
+
+// byteReader read varints keeping the first error
+type byteReader struct {
+	data []byte
+	err  error
+}
+
+func (r *byteReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errors.New("invalid uvarint")
+		return 0
+	}
+
+	r.data = r.data[n:]
+
+	return value
+}
+
+func (r *byteReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+
+	value, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = errors.New("invalid varint")
+		return 0
+	}
+
+	r.data = r.data[n:]
+
+	return value
+}
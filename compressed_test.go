@@ -0,0 +1,143 @@
+package arrayfuncs_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressedIntArray(t *testing.T) {
+	sortedIDs := func(size int) (res arrayFuncs.Array[int64]) {
+		random := rand.New(rand.NewSource(int64(size)))
+
+		res = make(arrayFuncs.Array[int64], size)
+
+		value := int64(1_000_000)
+		for i := range res {
+			value += random.Int63n(20)
+			res[i] = value
+		}
+
+		return
+	}
+
+	t.Run("AtAndToArray", func(t *testing.T) {
+		values := sortedIDs(1000)
+		c := arrayFuncs.NewCompressedIntArray(values...)
+
+		assert.Equal(t, 1000, c.Len())
+		assert.Equal(t, values, c.ToArray())
+
+		for _, i := range []int{0, 1, 127, 128, 129, 999} {
+			assert.Equal(t, values[i], *c.At(i))
+		}
+
+		assert.Equal(t, values[999], *c.At(-1))
+		assert.Nil(t, c.At(1000))
+		assert.Nil(t, c.At(-1001))
+	})
+
+	t.Run("SortedCompression", func(t *testing.T) {
+		c := arrayFuncs.NewCompressedIntArray(sortedIDs(10000)...)
+
+		data, err := c.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Less(t, len(data), 10000*2)
+	})
+
+	t.Run("AllBreak", func(t *testing.T) {
+		c := arrayFuncs.NewCompressedIntArray(sortedIDs(300)...)
+
+		count := 0
+		for i := range c.All() {
+			assert.Equal(t, count, i)
+
+			count++
+			if count == 200 {
+				break
+			}
+		}
+
+		assert.Equal(t, 200, count)
+	})
+
+	t.Run("IncludesSorted", func(t *testing.T) {
+		values := sortedIDs(1000)
+		c := arrayFuncs.NewCompressedIntArray(values...)
+		set := make(map[int64]bool)
+
+		for _, v := range values {
+			set[v] = true
+			assert.True(t, c.Includes(v))
+		}
+
+		for v := values[0] - 5; v < values[999]+5; v++ {
+			assert.Equal(t, set[v], c.Includes(v))
+		}
+	})
+
+	t.Run("IncludesUnsorted", func(t *testing.T) {
+		var c arrayFuncs.CompressedIntArray
+
+		values := arrayFuncs.Array[int64]{5, math.MaxInt64, math.MinInt64, -3, 0}
+		for i := 0; i < 200; i++ {
+			values = append(values, int64(i*7%50))
+		}
+
+		c.Append(values...)
+
+		assert.Equal(t, values, c.ToArray())
+		assert.True(t, c.Includes(math.MinInt64))
+		assert.True(t, c.Includes(49))
+		assert.False(t, c.Includes(50))
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		values := sortedIDs(500)
+		c := arrayFuncs.NewCompressedIntArray(values[:100]...)
+
+		c.Append(values[100:300]...)
+		c.Append(values[300:]...)
+
+		assert.Equal(t, values, c.ToArray())
+		assert.True(t, c.Includes(values[450]))
+	})
+
+	t.Run("Marshal", func(t *testing.T) {
+		for _, size := range []int{0, 1, 128, 129, 1000} {
+			values := sortedIDs(size)
+
+			data, err := arrayFuncs.NewCompressedIntArray(values...).MarshalBinary()
+			assert.NoError(t, err)
+
+			var c arrayFuncs.CompressedIntArray
+			assert.NoError(t, c.UnmarshalBinary(data))
+			assert.Equal(t, size, c.Len())
+			assert.Equal(t, values, c.ToArray())
+
+			if size > 0 {
+				assert.True(t, c.Includes(values[size/2]))
+			}
+		}
+	})
+
+	t.Run("InvalidData", func(t *testing.T) {
+		data, err := arrayFuncs.NewCompressedIntArray(sortedIDs(300)...).MarshalBinary()
+		assert.NoError(t, err)
+
+		var c arrayFuncs.CompressedIntArray
+
+		assert.ErrorIs(t, c.UnmarshalBinary([]byte("nope")), arrayFuncs.ErrInvalidFormat)
+
+		future := append([]byte{}, data...)
+		future[4] = 2
+		assert.ErrorIs(t, c.UnmarshalBinary(future), arrayFuncs.ErrUnsupportedVersion)
+
+		assert.ErrorIs(t, c.UnmarshalBinary(data[:len(data)-1]), arrayFuncs.ErrInvalidFormat)
+		assert.ErrorIs(t, c.UnmarshalBinary(data[:8]), arrayFuncs.ErrInvalidFormat)
+		assert.Equal(t, 0, c.Len())
+	})
+}
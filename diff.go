@@ -0,0 +1,272 @@
+package arrayfuncs
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPatchMismatch is returned when the edit script doesn't match the Array it is applied on
+var ErrPatchMismatch = errors.New("arrayfuncs: edit script doesn't match the array")
+
+// EditKind is the kind of operation of an edit script
+type EditKind string
+
+const (
+	// EditKeep means the element at OldIndex is unchanged and goes to NewIndex
+	EditKeep EditKind = "keep"
+	// EditUpdate means the element at OldIndex has the same key but its value changed to Value on NewIndex
+	EditUpdate EditKind = "update"
+	// EditDelete means the element at OldIndex was removed
+	EditDelete EditKind = "delete"
+	// EditInsert means Value was inserted at NewIndex
+	EditInsert EditKind = "insert"
+	// EditMove means the element at OldIndex was moved to NewIndex, with Value as its new value
+	EditMove EditKind = "move"
+)
+
+// EditOp is one operation of an edit script
+type EditOp[T comparable] struct {
+	Kind     EditKind `json:"op"`
+	OldIndex int      `json:"oldIndex"`
+	NewIndex int      `json:"newIndex"`
+	Value    T        `json:"value"`
+	OldValue T        `json:"oldValue"`
+}
+
+// EditScript is the list of operations that transform an Array into another, it can be serialized to JSON
+type EditScript[T comparable] []EditOp[T]
+
+/*
+Diff return the minimal edit script that transform old into new, using the Myers algorithm over the keys.
+Elements with the same key are the same record, if their values are different an EditUpdate is generated.
+A record deleted from one place and inserted in other is reported as EditMove
+
+	old := Array[User]{{1, "ann"}, {2, "bob"}}
+	new := Array[User]{{2, "bob"}, {3, "carl"}}
+
+	script := Diff(old, new, func(u User) int { return u.ID })
+
+	// script is: delete ann, keep bob, insert carl
+	patched, _ := Patch(old, script) // patched is equal to new
+*/
+func Diff[T, K comparable](old, new Array[T], key func(v T) K) (script EditScript[T]) {
+	var (
+		oldKeys = make([]K, len(old))
+		newKeys = make([]K, len(new))
+	)
+
+	for i := range old {
+		oldKeys[i] = key(old[i])
+	}
+
+	for i := range new {
+		newKeys[i] = key(new[i])
+	}
+
+	for _, step := range myers(oldKeys, newKeys) {
+		op := EditOp[T]{Kind: step.kind, OldIndex: step.oldIndex, NewIndex: step.newIndex}
+
+		switch op.Kind {
+		case EditKeep:
+			op.Value = new[op.NewIndex]
+			op.OldValue = old[op.OldIndex]
+
+			if op.Value != op.OldValue {
+				op.Kind = EditUpdate
+			}
+		case EditDelete:
+			op.OldValue = old[op.OldIndex]
+		case EditInsert:
+			op.Value = new[op.NewIndex]
+		}
+
+		script = append(script, op)
+	}
+
+	return detectMoves(script, key)
+}
+
+/*
+Patch apply the edit script on the Array and return the new Array.
+If the script wasn't generated from an Array equal to arr an ErrPatchMismatch is returned
+*/
+func Patch[T comparable](arr Array[T], script EditScript[T]) (res Array[T], err error) {
+	var (
+		length = 0
+		used   = make([]bool, len(arr))
+	)
+
+	for _, op := range script {
+		if op.Kind != EditDelete {
+			length++
+		}
+	}
+
+	res = make(Array[T], length)
+	filled := make([]bool, length)
+
+	for _, op := range script {
+		if op.Kind != EditInsert {
+			if op.OldIndex < 0 || op.OldIndex >= len(arr) || used[op.OldIndex] || arr[op.OldIndex] != op.OldValue {
+				return nil, fmt.Errorf("%w: %s of old index %d", ErrPatchMismatch, op.Kind, op.OldIndex)
+			}
+
+			used[op.OldIndex] = true
+		}
+
+		if op.Kind == EditDelete {
+			continue
+		}
+
+		if op.NewIndex < 0 || op.NewIndex >= length || filled[op.NewIndex] {
+			return nil, fmt.Errorf("%w: %s to new index %d", ErrPatchMismatch, op.Kind, op.NewIndex)
+		}
+
+		filled[op.NewIndex] = true
+		res[op.NewIndex] = op.Value
+	}
+
+	for i := range used {
+		if !used[i] {
+			return nil, fmt.Errorf("%w: old index %d isn't in the script", ErrPatchMismatch, i)
+		}
+	}
+
+	return
+}
+
+// editStep is one operation found by myers, before knowing the values
+type editStep struct {
+	kind     EditKind
+	oldIndex int
+	newIndex int
+}
+
+// myers return the keep, delete and insert operations of the shortest edit script between a and b
+func myers[K comparable](a, b []K) (steps []editStep) {
+	var (
+		n      = len(a)
+		m      = len(b)
+		limit  = n + m
+		offset = limit + 1
+		v      = make([]int, 2*offset+1)
+		trace  [][]int
+	)
+
+	found := false
+
+	for d := 0; d <= limit && !found; d++ {
+		trace = append(trace, append([]int{}, v...))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Walk the trace back from the end to build the script
+	x, y := n, m
+	reversed := []editStep{}
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, editStep{EditKeep, x, y})
+		}
+
+		if d == 0 {
+			break
+		}
+
+		if x == prevX {
+			y--
+			reversed = append(reversed, editStep{EditInsert, -1, y})
+		} else {
+			x--
+			reversed = append(reversed, editStep{EditDelete, x, -1})
+		}
+
+		x, y = prevX, prevY
+	}
+
+	for i := len(reversed) - 1; i >= 0; i-- {
+		steps = append(steps, reversed[i])
+	}
+
+	return
+}
+
+// detectMoves replace the pairs of delete and insert of the same key by a move
+func detectMoves[T, K comparable](script EditScript[T], key func(v T) K) (res EditScript[T]) {
+	deleted := map[K][]int{}
+
+	for i, op := range script {
+		if op.Kind == EditDelete {
+			k := key(op.OldValue)
+			deleted[k] = append(deleted[k], i)
+		}
+	}
+
+	moved := make(map[int]bool)
+
+	for i := range script {
+		op := &script[i]
+
+		if op.Kind != EditInsert {
+			continue
+		}
+
+		k := key(op.Value)
+
+		if candidates := deleted[k]; len(candidates) > 0 {
+			from := script[candidates[0]]
+			deleted[k] = candidates[1:]
+			moved[candidates[0]] = true
+
+			op.Kind = EditMove
+			op.OldIndex = from.OldIndex
+			op.OldValue = from.OldValue
+		}
+	}
+
+	for i := range script {
+		if !moved[i] {
+			res = append(res, script[i])
+		}
+	}
+
+	return
+}
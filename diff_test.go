@@ -0,0 +1,106 @@
+package arrayfuncs_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type record struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func recordID(r record) int {
+	return r.ID
+}
+
+func TestDiff(t *testing.T) {
+
+	t.Run("Operations", func(t *testing.T) {
+		var (
+			old = arrayFuncs.Array[record]{{1, "ann"}, {2, "bob"}, {3, "carl"}}
+			new = arrayFuncs.Array[record]{{2, "bob"}, {3, "carlos"}, {4, "dan"}}
+		)
+
+		script := arrayFuncs.Diff(old, new, recordID)
+
+		assert.Equal(t, arrayFuncs.EditScript[record]{
+			{Kind: arrayFuncs.EditDelete, OldIndex: 0, NewIndex: -1, OldValue: record{1, "ann"}},
+			{Kind: arrayFuncs.EditKeep, OldIndex: 1, NewIndex: 0, Value: record{2, "bob"}, OldValue: record{2, "bob"}},
+			{Kind: arrayFuncs.EditUpdate, OldIndex: 2, NewIndex: 1, Value: record{3, "carlos"}, OldValue: record{3, "carl"}},
+			{Kind: arrayFuncs.EditInsert, OldIndex: -1, NewIndex: 2, Value: record{4, "dan"}},
+		}, script)
+	})
+
+	t.Run("Move", func(t *testing.T) {
+		var (
+			old = arrayFuncs.Array[int]{1, 2, 3, 4}
+			new = arrayFuncs.Array[int]{2, 3, 4, 1}
+		)
+
+		script := arrayFuncs.Diff(old, new, func(v int) int { return v })
+
+		assert.Equal(t, arrayFuncs.EditScript[int]{
+			{Kind: arrayFuncs.EditKeep, OldIndex: 1, NewIndex: 0, Value: 2, OldValue: 2},
+			{Kind: arrayFuncs.EditKeep, OldIndex: 2, NewIndex: 1, Value: 3, OldValue: 3},
+			{Kind: arrayFuncs.EditKeep, OldIndex: 3, NewIndex: 2, Value: 4, OldValue: 4},
+			{Kind: arrayFuncs.EditMove, OldIndex: 0, NewIndex: 3, Value: 1, OldValue: 1},
+		}, script)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var (
+			old = arrayFuncs.Array[record]{{1, "ann"}, {2, "bob"}}
+			new = arrayFuncs.Array[record]{{2, "bobby"}, {1, "ann"}}
+		)
+
+		encoded, err := json.Marshal(arrayFuncs.Diff(old, new, recordID))
+		assert.Nil(t, err)
+
+		var script arrayFuncs.EditScript[record]
+		assert.Nil(t, json.Unmarshal(encoded, &script))
+
+		patched, err := arrayFuncs.Patch(old, script)
+		assert.Nil(t, err)
+		assert.Equal(t, new, patched)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		script := arrayFuncs.Diff(arrayFuncs.Array[int]{1, 2}, arrayFuncs.Array[int]{2}, func(v int) int { return v })
+
+		_, err := arrayFuncs.Patch(arrayFuncs.Array[int]{5, 2}, script)
+		assert.ErrorIs(t, err, arrayFuncs.ErrPatchMismatch)
+
+		_, err = arrayFuncs.Patch(arrayFuncs.Array[int]{1, 2, 3}, script)
+		assert.ErrorIs(t, err, arrayFuncs.ErrPatchMismatch)
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		random := rand.New(rand.NewSource(7))
+
+		generate := func() (res arrayFuncs.Array[record]) {
+			for _, id := range random.Perm(12)[:random.Intn(12)] {
+				res = append(res, record{id, []string{"a", "b"}[random.Intn(2)]})
+			}
+
+			return
+		}
+
+		for i := 0; i < 500; i++ {
+			old, new := generate(), generate()
+
+			patched, err := arrayFuncs.Patch(old, arrayFuncs.Diff(old, new, recordID))
+
+			assert.Nil(t, err)
+			assert.Equal(t, len(new), len(patched))
+
+			for j := range new {
+				assert.Equal(t, new[j], patched[j])
+			}
+		}
+	})
+}
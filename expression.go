@@ -0,0 +1,507 @@
+package arrayfuncs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ExpressionError is returned when a predicate expression can't be compiled
+type ExpressionError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ExpressionError) Error() string {
+	return fmt.Sprintf("expression: line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+/*
+CompilePredicate compile the expression into a callback that can be passed to Filter, Find, FindIndex and Every, and to Some with Predicate.Value.
+The elements must be structs or pointers to structs, and the fields are found by name or json tag like on Query.
+
+	active, err := CompilePredicate[User](`status == "active" && len(tags) > 2`)
+
+	users.Filter(active)
+
+➡ Supported operators:
+
+	== != < <= > >=           comparison of numbers, strings and booleans
+	&& || !                   boolean logic, with parentheses
+	in                        value in ["a", "b"] or value in a slice field
+	contains                  a string contains other, or a slice contains a value
+	startsWith endsWith       prefix and suffix of strings
+	matches =~                regular expression match
+	len(value)                length of strings, slices and maps
+
+The strings use single or double quotes with the same escapes as Query.
+When the values can't be compared, like a string with a number, the predicate returns false
+*/
+func CompilePredicate[T comparable](expression string) (predicate Predicate[T], err error) {
+	tokens, err := tokenizeExpression(expression)
+	if err != nil {
+		return
+	}
+
+	p := &expressionParser{tokens: tokens, elementType: reflect.TypeOf((*T)(nil)).Elem()}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return
+	}
+
+	if t := p.next(); t.kind != tokenEOF {
+		return nil, p.fail(t, "unexpected %q", t.text)
+	}
+
+	predicate = func(v *T, i int) bool {
+		result, _ := root.eval(reflect.ValueOf(v).Elem()).(bool)
+		return result
+	}
+
+	return
+}
+
+/*
+Predicate is a compiled expression. It has the signature of the Filter, Find, FindIndex and Every callbacks,
+and Value return it with the signature of the callbacks that receive the element by value
+
+	users.Some(active.Value())
+*/
+type Predicate[T comparable] func(v *T, i int) bool
+
+// Value return the predicate as a callback that receive the element by value, like Some
+func (p Predicate[T]) Value() func(v T, i int) bool {
+	return func(v T, i int) bool {
+		return p(&v, i)
+	}
+}
+
+// MustCompilePredicate is like CompilePredicate but panics if the expression is invalid
+func MustCompilePredicate[T comparable](expression string) Predicate[T] {
+	predicate, err := CompilePredicate[T](expression)
+	if err != nil {
+		panic(err)
+	}
+
+	return predicate
+}
+
+// expressionRules are the lexer rules of the expressions, the names include the dots of nested fields
+var expressionRules = lexRules{ //nolint:gochecknoglobals
+	identRune: func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+	},
+	signedNumbers: true,
+	operatorStart: "=!<>&|",
+	operatorNext:  "=&|~",
+	punctuation: map[rune]tokenKind{
+		',': tokenComma,
+		'(': tokenLeftParen,
+		')': tokenRightParen,
+		'[': tokenLeftBracket,
+		']': tokenRightBracket,
+	},
+}
+
+func tokenizeExpression(expression string) (tokens []token, err error) {
+	tokens, lexErr := lex(expression, expressionRules)
+	if lexErr != nil {
+		return nil, &ExpressionError{lexErr.line, lexErr.column, lexErr.message}
+	}
+
+	return
+}
+
+type expressionNode interface {
+	eval(v reflect.Value) any
+}
+
+type expressionParser struct {
+	tokens      []token
+	position    int
+	elementType reflect.Type
+}
+
+func (p *expressionParser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *expressionParser) next() token {
+	t := p.tokens[p.position]
+
+	if t.kind != tokenEOF {
+		p.position++
+	}
+
+	return t
+}
+
+func (p *expressionParser) fail(t token, format string, args ...any) error {
+	return &ExpressionError{t.line, t.column, fmt.Sprintf(format, args...)}
+}
+
+func (p *expressionParser) parseOr() (expressionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && p.peek().text == "||" {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &booleanNode{or: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseAnd() (expressionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOperator && p.peek().text == "&&" {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &booleanNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *expressionParser) parseUnary() (expressionNode, error) {
+	if t := p.peek(); t.kind == tokenOperator && t.text == "!" {
+		p.next()
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &negationNode{operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *expressionParser) parseComparison() (expressionNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+
+	var operator string
+
+	switch {
+	case t.kind == tokenOperator && t.text != "&&" && t.text != "||" && t.text != "!":
+		operator = t.text
+	case t.is("in"), t.is("contains"), t.is("startsWith"), t.is("endsWith"), t.is("matches"):
+		keywords := map[string]string{
+			"in":         "in",
+			"contains":   "contains",
+			"startswith": "startsWith",
+			"endswith":   "endsWith",
+			"matches":    "matches",
+		}
+
+		operator = keywords[strings.ToLower(t.text)]
+	default:
+		return left, nil
+	}
+
+	p.next()
+
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	switch operator {
+	case "==", "!=", "<", "<=", ">", ">=", "in", "contains", "startsWith", "endsWith":
+		return &operatorNode{operator: operator, left: left, right: right}, nil
+	case "matches", "=~":
+		var text string
+
+		if pattern, ok := right.(*constantNode); ok {
+			text, ok = pattern.value.(string)
+
+			if !ok {
+				return nil, p.fail(t, "%s needs a string pattern", operator)
+			}
+		} else {
+			return nil, p.fail(t, "%s needs a string pattern", operator)
+		}
+
+		compiled, err := regexp.Compile(text)
+		if err != nil {
+			return nil, p.fail(t, "invalid pattern: %s", err)
+		}
+
+		return &matchNode{value: left, pattern: compiled}, nil
+	}
+
+	return nil, p.fail(t, "unknown operator %q", operator)
+}
+
+func (p *expressionParser) parsePrimary() (expressionNode, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, p.fail(t, "invalid number %q", t.text)
+		}
+
+		return &constantNode{value}, nil
+	case tokenString:
+		return &constantNode{t.text}, nil
+	case tokenLeftParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, p.fail(closing, "expected ')', found %q", closing.text)
+		}
+
+		return inner, nil
+	case tokenLeftBracket:
+		list := &listNode{}
+
+		for p.peek().kind != tokenRightBracket {
+			item, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+
+			list.items = append(list.items, item)
+
+			if p.peek().kind == tokenComma {
+				p.next()
+			} else if p.peek().kind != tokenRightBracket {
+				return nil, p.fail(p.peek(), "expected ',' or ']', found %q", p.peek().text)
+			}
+		}
+
+		p.next()
+
+		return list, nil
+	case tokenIdent:
+		switch {
+		case t.is("true"), t.is("false"):
+			return &constantNode{t.is("true")}, nil
+		case t.is("nil"), t.is("null"):
+			return &constantNode{nil}, nil
+		case t.text == "len" && p.peek().kind == tokenLeftParen:
+			p.next()
+
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+
+			if closing := p.next(); closing.kind != tokenRightParen {
+				return nil, p.fail(closing, "expected ')', found %q", closing.text)
+			}
+
+			return &lengthNode{inner}, nil
+		}
+
+		path, err := resolveFieldPath(p.elementType, t.text)
+		if err != nil {
+			return nil, p.fail(t, "%s", err)
+		}
+
+		return &fieldNode{path}, nil
+	}
+
+	return nil, p.fail(t, "unexpected %q", t.text)
+}
+
+type constantNode struct {
+	value any
+}
+
+func (c *constantNode) eval(reflect.Value) any {
+	return c.value
+}
+
+type fieldNode struct {
+	path *fieldPath
+}
+
+func (f *fieldNode) eval(v reflect.Value) any {
+	return f.path.get(v)
+}
+
+type listNode struct {
+	items []expressionNode
+}
+
+func (l *listNode) eval(v reflect.Value) any {
+	res := make([]any, len(l.items))
+
+	for i := range l.items {
+		res[i] = l.items[i].eval(v)
+	}
+
+	return res
+}
+
+type lengthNode struct {
+	value expressionNode
+}
+
+func (l *lengthNode) eval(v reflect.Value) any {
+	value := reflect.ValueOf(l.value.eval(v))
+
+	switch value.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len())
+	}
+
+	return nil
+}
+
+type booleanNode struct {
+	or          bool
+	left, right expressionNode
+}
+
+func (b *booleanNode) eval(v reflect.Value) any {
+	left, _ := b.left.eval(v).(bool)
+
+	if left == b.or {
+		return left
+	}
+
+	right, _ := b.right.eval(v).(bool)
+
+	return right
+}
+
+type negationNode struct {
+	operand expressionNode
+}
+
+func (n *negationNode) eval(v reflect.Value) any {
+	value, _ := n.operand.eval(v).(bool)
+
+	return !value
+}
+
+type matchNode struct {
+	value   expressionNode
+	pattern *regexp.Regexp
+}
+
+func (m *matchNode) eval(v reflect.Value) any {
+	text, ok := normalizeValue(m.value.eval(v)).(string)
+
+	return ok && m.pattern.MatchString(text)
+}
+
+type operatorNode struct {
+	operator    string
+	left, right expressionNode
+}
+
+func (o *operatorNode) eval(v reflect.Value) any {
+	left, right := o.left.eval(v), o.right.eval(v)
+
+	switch o.operator {
+	case "==":
+		return equalValues(left, right)
+	case "!=":
+		return !equalValues(left, right)
+	case "in":
+		return containsValue(right, left)
+	case "contains":
+		if text, ok := normalizeValue(left).(string); ok {
+			part, ok := normalizeValue(right).(string)
+			return ok && strings.Contains(text, part)
+		}
+
+		return containsValue(left, right)
+	case "startsWith", "endsWith":
+		text, okText := normalizeValue(left).(string)
+		part, okPart := normalizeValue(right).(string)
+
+		if !okText || !okPart {
+			return false
+		}
+
+		if o.operator == "startsWith" {
+			return strings.HasPrefix(text, part)
+		}
+
+		return strings.HasSuffix(text, part)
+	}
+
+	if left == nil || right == nil {
+		return false
+	}
+
+	result, err := compareValues(left, right)
+	if err != nil {
+		return false
+	}
+
+	switch o.operator {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	}
+
+	return result >= 0
+}
+
+func equalValues(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	result, err := compareValues(a, b)
+
+	return err == nil && result == 0
+}
+
+// containsValue return true if the list, a slice or an array, has the value
+func containsValue(list, value any) bool {
+	items := reflect.ValueOf(list)
+
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < items.Len(); i++ {
+		if equalValues(items.Index(i).Interface(), value) {
+			return true
+		}
+	}
+
+	return false
+}
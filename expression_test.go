@@ -0,0 +1,111 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Tags    []string `json:"tags"`
+	Score   float64
+	Address *address
+}
+
+func TestCompilePredicate(t *testing.T) {
+	accounts := arrayFuncs.Array[*account]{
+		{"ann", "active", []string{"go", "js", "sql"}, 9.5, &address{"Lisbon"}},
+		{"bob", "active", []string{"go"}, 7, nil},
+		{"carl", "blocked", []string{"go", "rust", "c"}, 3, &address{"Porto"}},
+	}
+
+	names := func(expression string) (res []string) {
+		predicate := arrayFuncs.MustCompilePredicate[*account](expression)
+
+		for _, a := range accounts.Filter(predicate) {
+			res = append(res, a.Name)
+		}
+
+		return
+	}
+
+	t.Run("Operators", func(t *testing.T) {
+		cases := map[string][]string{
+			`status == "active" && len(tags) > 2`:      {"ann"},
+			`status != 'active' || Score >= 9.5`:       {"ann", "carl"},
+			`!(Score < 5)`:                             {"ann", "bob"},
+			`name in ["bob", "carl"]`:                  {"bob", "carl"},
+			`"rust" in tags`:                           {"carl"},
+			`tags contains "js"`:                       {"ann"},
+			`name contains "o"`:                        {"bob"},
+			`name startsWith "c" || name endsWith "n"`: {"ann", "carl"},
+			`name matches "^[ab]"`:                     {"ann", "bob"},
+			`Address.city =~ "^Por"`:                   {"carl"},
+			`Address == nil`:                           {"bob"},
+			`name == 1`:                                nil,
+		}
+
+		for expression, expected := range cases {
+			assert.Equal(t, expected, names(expression), expression)
+		}
+	})
+
+	t.Run("SameSignatureAsCallbacks", func(t *testing.T) {
+		predicate, err := arrayFuncs.CompilePredicate[*account](`Score > 5`)
+		assert.Nil(t, err)
+
+		assert.Equal(t, "ann", (*accounts.Find(predicate)).Name)
+		assert.Equal(t, 1, *accounts.FindLastIndex(predicate))
+		assert.False(t, accounts.Every(predicate))
+		assert.True(t, accounts.Some(predicate.Value()))
+		assert.False(t, accounts.Some(arrayFuncs.MustCompilePredicate[*account](`Score > 10`).Value()))
+	})
+
+	t.Run("EscapedQuotes", func(t *testing.T) {
+		type quote struct {
+			Text string
+		}
+
+		quotes := arrayFuncs.Array[quote]{{`it's`}, {`say "hi"`}, {`a\b`}}
+
+		cases := map[string]int{
+			`Text == 'it\'s'`:      0,
+			`Text == 'it''s'`:      0,
+			`Text == "it's"`:       0,
+			`Text == 'say "hi"'`:   1,
+			`Text == "say \"hi\""`: 1,
+			`Text == 'a\\b'`:       2,
+		}
+
+		for expression, index := range cases {
+			predicate, err := arrayFuncs.CompilePredicate[quote](expression)
+
+			if assert.Nil(t, err, expression) {
+				assert.Equal(t, index, *quotes.FindIndex(predicate), expression)
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[string][2]int{
+			`unknown == 1`:              {1, 1},
+			`name == "open`:             {1, 9},
+			`name = "a"`:                {1, 6},
+			`name matches "["`:          {1, 6},
+			"status == 'a' &&\n  (name": {2, 8},
+			`name == "a" score`:         {1, 13},
+		}
+
+		for expression, position := range cases {
+			_, err := arrayFuncs.CompilePredicate[*account](expression)
+
+			expressionErr, ok := err.(*arrayFuncs.ExpressionError)
+			if assert.True(t, ok, expression) {
+				assert.Equal(t, position, [2]int{expressionErr.Line, expressionErr.Column}, expression)
+			}
+		}
+	})
+}
@@ -0,0 +1,326 @@
+package arrayfuncs
+
+import (
+	"bufio"
+	"container/heap"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"unsafe"
+)
+
+// defaultMemoryBudget is the memory used by each run when ExternalSortConfig.MemoryBudget is 0
+const defaultMemoryBudget = 64 << 20
+
+// StreamEncoder write values one after the other on a stream, like gob.Encoder and json.Encoder
+type StreamEncoder interface {
+	Encode(v any) error
+}
+
+// StreamDecoder read the values written by a StreamEncoder, returning io.EOF at the end of the stream
+type StreamDecoder interface {
+	Decode(v any) error
+}
+
+/*
+ExternalSortConfig configure ExternalSort
+
+	Context       stop the sort when it is done, yielding its error. context.Background by default
+	Compare       the order of the elements, required
+	NewEncoder    create the encoder of a run file, gob by default
+	NewDecoder    create the decoder of a run file, gob by default
+	MemoryBudget  the bytes kept in memory before a run is spilled to disk, 64MB by default
+	Size          the bytes used by an element, unsafe.Sizeof by default
+	TempDir       where the run files are created, os.TempDir by default
+
+➡ unsafe.Sizeof counts only the element itself, not the memory it points to, like the bytes of strings and slices.
+When T has them set Size, or the runs can be much bigger than the MemoryBudget
+
+	Size: func(r Row) int { return int(unsafe.Sizeof(r)) + len(r.Name) },
+*/
+type ExternalSortConfig[T comparable] struct {
+	Context      context.Context
+	Compare      Comparator[T]
+	NewEncoder   func(w io.Writer) StreamEncoder
+	NewDecoder   func(r io.Reader) StreamDecoder
+	MemoryBudget int
+	Size         func(v T) int
+	TempDir      string
+}
+
+func (c *ExternalSortConfig[T]) setDefaults() {
+	if c.Context == nil {
+		c.Context = context.Background()
+	}
+
+	if c.NewEncoder == nil {
+		c.NewEncoder = func(w io.Writer) StreamEncoder { return gob.NewEncoder(w) }
+	}
+
+	if c.NewDecoder == nil {
+		c.NewDecoder = func(r io.Reader) StreamDecoder { return gob.NewDecoder(r) }
+	}
+
+	if c.MemoryBudget <= 0 {
+		c.MemoryBudget = defaultMemoryBudget
+	}
+
+	if c.Size == nil {
+		var zero T
+
+		size := int(unsafe.Sizeof(zero))
+		c.Size = func(T) int { return size }
+	}
+}
+
+/*
+ExternalSort sorts a sequence that may not fit in memory.
+The elements are read until the memory budget is reached, then that run is sorted and written to a temp file.
+At the end the runs are merged and the sorted elements are yield lazily, with a nil error.
+The sort is stable.
+
+If something fails, or the Context is done, the error is yield with the zero value of T and the iteration stops.
+The temp files are removed when the iteration ends, and an error removing them is yield too.
+When the loop is broken before the end the files are removed, but there is no way to report their errors
+
+	sorted := ExternalSort(rows, ExternalSortConfig[Row]{
+		Compare:      Ascending(func(r Row) int64 { return r.ID }),
+		MemoryBudget: 256 << 20,
+	})
+
+	for row, err := range sorted {
+		if err != nil {
+			return err
+		}
+		...
+	}
+
+➡ When all the elements fit in the memory budget nothing is written to disk
+*/
+func ExternalSort[T comparable](seq iter.Seq[T], config ExternalSortConfig[T]) iter.Seq2[T, error] {
+	config.setDefaults()
+
+	return func(yield func(T, error) bool) {
+		var (
+			zero    T
+			runs    []*os.File
+			stopped bool
+		)
+
+		send := func(v T) bool {
+			stopped = !yield(v, nil)
+			return !stopped
+		}
+
+		err := externalSort(seq, config, &runs, send)
+
+		// Remove the run files on any exit
+		for _, run := range runs {
+			if removeErr := errors.Join(run.Close(), os.Remove(run.Name())); removeErr != nil {
+				err = errors.Join(err, fmt.Errorf("arrayfuncs: remove sort run: %w", removeErr))
+			}
+		}
+
+		if err != nil && !stopped {
+			yield(zero, err)
+		}
+	}
+}
+
+// externalSort sorts the sequence calling send with each element, the run files are added to runs so they can be removed
+func externalSort[T comparable](seq iter.Seq[T], config ExternalSortConfig[T], runs *[]*os.File, send func(v T) bool) error {
+	var (
+		buffer Array[T]
+		used   int
+	)
+
+	for v := range seq {
+		if err := config.Context.Err(); err != nil {
+			return fmt.Errorf("arrayfuncs: external sort: %w", err)
+		}
+
+		buffer = append(buffer, v)
+		used += config.Size(v)
+
+		if used < config.MemoryBudget {
+			continue
+		}
+
+		run, err := spillRun(buffer, config)
+		if run != nil {
+			*runs = append(*runs, run)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		buffer, used = buffer[:0], 0
+	}
+
+	sortRun(buffer, config.Compare)
+
+	// Everything fit in memory, there is nothing to merge
+	if len(*runs) == 0 {
+		for _, v := range buffer {
+			if err := config.Context.Err(); err != nil {
+				return fmt.Errorf("arrayfuncs: external sort: %w", err)
+			}
+
+			if !send(v) {
+				return nil
+			}
+		}
+
+		return nil
+	}
+
+	if len(buffer) > 0 {
+		run, err := spillRun(buffer, config)
+		if run != nil {
+			*runs = append(*runs, run)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return mergeRuns(*runs, config, send)
+}
+
+// sortRun sorts one run with Array.Sort
+func sortRun[T comparable](run Array[T], cmp Comparator[T]) {
+	run.Sort(func(i, j int) bool {
+		return cmp(run[i], run[j]) < 0
+	})
+}
+
+// spillRun sorts the run and write it on a new temp file, the file is returned even on error so it can be removed
+func spillRun[T comparable](run Array[T], config ExternalSortConfig[T]) (file *os.File, err error) {
+	sortRun(run, config.Compare)
+
+	file, err = os.CreateTemp(config.TempDir, "arrayfuncs-sort-*")
+	if err != nil {
+		return nil, fmt.Errorf("arrayfuncs: create sort run: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := config.NewEncoder(writer)
+
+	for _, v := range run {
+		if err = encoder.Encode(v); err != nil {
+			return file, fmt.Errorf("arrayfuncs: write sort run: %w", err)
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return file, fmt.Errorf("arrayfuncs: write sort run: %w", err)
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return file, fmt.Errorf("arrayfuncs: read sort run: %w", err)
+	}
+
+	return file, nil
+}
+
+// runCursor is the next element of one run on the merge
+type runCursor[T comparable] struct {
+	value   T
+	run     int
+	decoder StreamDecoder
+}
+
+// runHeap is the heap of the cursors ordered by value, with the first run winning ties so the merge is stable
+type runHeap[T comparable] struct {
+	cursors []*runCursor[T]
+	cmp     Comparator[T]
+}
+
+func (h *runHeap[T]) Len() int { return len(h.cursors) }
+
+func (h *runHeap[T]) Less(i, j int) bool {
+	if result := h.cmp(h.cursors[i].value, h.cursors[j].value); result != 0 {
+		return result < 0
+	}
+
+	return h.cursors[i].run < h.cursors[j].run
+}
+
+func (h *runHeap[T]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+
+func (h *runHeap[T]) Push(x any) { h.cursors = append(h.cursors, x.(*runCursor[T])) }
+
+func (h *runHeap[T]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+
+	return last
+}
+
+// advance read the next value of the cursor, returning false at the end of the run
+func (c *runCursor[T]) advance() (ok bool, err error) {
+	var v T
+
+	if err = c.decoder.Decode(&v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("arrayfuncs: read sort run: %w", err)
+	}
+
+	c.value = v
+
+	return true, nil
+}
+
+// mergeRuns send the elements of all the sorted runs in order, with a k-way merge
+func mergeRuns[T comparable](runs []*os.File, config ExternalSortConfig[T], send func(v T) bool) error {
+	h := &runHeap[T]{cmp: config.Compare}
+
+	for i, run := range runs {
+		cursor := &runCursor[T]{run: i, decoder: config.NewDecoder(bufio.NewReader(run))}
+
+		ok, err := cursor.advance()
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			h.cursors = append(h.cursors, cursor)
+		}
+	}
+
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		if err := config.Context.Err(); err != nil {
+			return fmt.Errorf("arrayfuncs: external sort: %w", err)
+		}
+
+		cursor := h.cursors[0]
+
+		if !send(cursor.value) {
+			return nil
+		}
+
+		ok, err := cursor.advance()
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package arrayfuncs_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type exportRow struct {
+	Group string
+	Order int
+}
+
+type failingEncoder struct{}
+
+func (failingEncoder) Encode(any) error { return errors.New("disk full") }
+
+func TestExternalSort(t *testing.T) {
+	ascending := arrayFuncs.Ascending(func(v int) int { return v })
+
+	randomSeq := func(size int) (values []int) {
+		random := rand.New(rand.NewSource(int64(size)))
+
+		values = make([]int, size)
+		for i := range values {
+			values[i] = random.Intn(1000) - 500
+		}
+
+		return
+	}
+
+	collect := func(t *testing.T, sorted func(yield func(int, error) bool)) (res []int) {
+		for v, err := range sorted {
+			assert.NoError(t, err)
+			res = append(res, v)
+		}
+
+		return
+	}
+
+	assertEmptyDir := func(t *testing.T, dir string) {
+		entries, err := os.ReadDir(dir)
+
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+	}
+
+	t.Run("ManyRuns", func(t *testing.T) {
+		var (
+			dir    = t.TempDir()
+			values = randomSeq(10000)
+		)
+
+		sorted := arrayFuncs.ExternalSort(slices.Values(values), arrayFuncs.ExternalSortConfig[int]{
+			Compare:      ascending,
+			MemoryBudget: 8 * 700,
+			TempDir:      dir,
+		})
+
+		res := collect(t, sorted)
+
+		slices.Sort(values)
+		assert.Equal(t, values, res)
+		assertEmptyDir(t, dir)
+	})
+
+	t.Run("InMemory", func(t *testing.T) {
+		dir := t.TempDir()
+
+		sorted := arrayFuncs.ExternalSort(slices.Values([]int{3, 1, 2}), arrayFuncs.ExternalSortConfig[int]{
+			Compare: ascending,
+			TempDir: dir,
+		})
+
+		assert.Equal(t, []int{1, 2, 3}, collect(t, sorted))
+		assertEmptyDir(t, dir)
+	})
+
+	t.Run("EmptySequence", func(t *testing.T) {
+		sorted := arrayFuncs.ExternalSort(slices.Values([]int{}), arrayFuncs.ExternalSortConfig[int]{Compare: ascending})
+
+		assert.Empty(t, collect(t, sorted))
+	})
+
+	t.Run("StableWithJSON", func(t *testing.T) {
+		dir := t.TempDir()
+
+		values := make([]exportRow, 0, 300)
+		for i := 0; i < 300; i++ {
+			values = append(values, exportRow{Group: string(rune('a' + i%3)), Order: i})
+		}
+
+		sorted := arrayFuncs.ExternalSort(slices.Values(values), arrayFuncs.ExternalSortConfig[exportRow]{
+			Compare:      arrayFuncs.Ascending(func(v exportRow) string { return v.Group }),
+			NewEncoder:   func(w io.Writer) arrayFuncs.StreamEncoder { return json.NewEncoder(w) },
+			NewDecoder:   func(r io.Reader) arrayFuncs.StreamDecoder { return json.NewDecoder(r) },
+			MemoryBudget: 40,
+			Size:         func(exportRow) int { return 1 },
+			TempDir:      dir,
+		})
+
+		var res []exportRow
+
+		for v, err := range sorted {
+			assert.NoError(t, err)
+			res = append(res, v)
+		}
+
+		assert.Len(t, res, 300)
+
+		for i := 1; i < len(res); i++ {
+			assert.True(t, res[i-1].Group < res[i].Group || res[i-1].Group == res[i].Group && res[i-1].Order < res[i].Order)
+		}
+
+		assertEmptyDir(t, dir)
+	})
+
+	t.Run("BreakRemovesFiles", func(t *testing.T) {
+		dir := t.TempDir()
+
+		sorted := arrayFuncs.ExternalSort(slices.Values(randomSeq(1000)), arrayFuncs.ExternalSortConfig[int]{
+			Compare:      ascending,
+			MemoryBudget: 8 * 100,
+			TempDir:      dir,
+		})
+
+		count := 0
+
+		for _, err := range sorted {
+			assert.NoError(t, err)
+
+			count++
+			if count == 10 {
+				break
+			}
+		}
+
+		assert.Equal(t, 10, count)
+		assertEmptyDir(t, dir)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		dir := t.TempDir()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		sorted := arrayFuncs.ExternalSort(slices.Values(randomSeq(1000)), arrayFuncs.ExternalSortConfig[int]{
+			Context:      ctx,
+			Compare:      ascending,
+			MemoryBudget: 8 * 100,
+			TempDir:      dir,
+		})
+
+		var (
+			count int
+			errs  []error
+		)
+
+		for _, err := range sorted {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			count++
+			if count == 10 {
+				cancel()
+			}
+		}
+
+		assert.Equal(t, 10, count)
+		assert.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], context.Canceled)
+		assertEmptyDir(t, dir)
+	})
+
+	t.Run("RemoveError", func(t *testing.T) {
+		dir := t.TempDir()
+
+		sorted := arrayFuncs.ExternalSort(slices.Values(randomSeq(1000)), arrayFuncs.ExternalSortConfig[int]{
+			Compare:      ascending,
+			MemoryBudget: 8 * 100,
+			TempDir:      dir,
+		})
+
+		var (
+			values []int
+			errs   []error
+		)
+
+		for v, err := range sorted {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+
+			// Remove the run files while they are merged, so ExternalSort can't remove them
+			if len(values) == 0 {
+				entries, _ := os.ReadDir(dir)
+				for _, entry := range entries {
+					assert.NoError(t, os.Remove(filepath.Join(dir, entry.Name())))
+				}
+			}
+
+			values = append(values, v)
+		}
+
+		assert.Len(t, values, 1000)
+		assert.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], os.ErrNotExist)
+	})
+
+	t.Run("EncoderError", func(t *testing.T) {
+		dir := t.TempDir()
+
+		sorted := arrayFuncs.ExternalSort(slices.Values(randomSeq(100)), arrayFuncs.ExternalSortConfig[int]{
+			Compare:      ascending,
+			NewEncoder:   func(io.Writer) arrayFuncs.StreamEncoder { return failingEncoder{} },
+			MemoryBudget: 8 * 10,
+			TempDir:      dir,
+		})
+
+		var errs []error
+
+		for _, err := range sorted {
+			errs = append(errs, err)
+		}
+
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "disk full")
+		assertEmptyDir(t, dir)
+	})
+}
@@ -472,7 +472,47 @@ func (l *Array[T]) Sort(callback func(index1, index2 int) bool) {
 	sort.SliceStable(*l, callback)
 }
 
-func (l *Array[T]) Splice() {}
+/*
+Splice remove 'deleteCount' elements starting at 'start' and insert the items in their place.
+Return an Array with the removed elements.
+Accepts negative start, representing descend way
+
+	a := Array[int]{1, 2, 3, 4, 5}
+	removed := a.Splice(1, 2, 10, 20, 30)
+
+	// 'a' variable now is Array[int]{1, 10, 20, 30, 4, 5} and 'removed' is Array[int]{2, 3}
+*/
+func (l *Array[T]) Splice(start, deleteCount int, items ...T) (removed Array[T]) {
+	length := len(*l)
+
+	if start < 0 {
+		start += length
+
+		if start < 0 {
+			start = 0
+		}
+	} else if start > length {
+		start = length
+	}
+
+	if deleteCount < 0 {
+		deleteCount = 0
+	} else if deleteCount > length-start {
+		deleteCount = length - start
+	}
+
+	removed = make(Array[T], deleteCount)
+	copy(removed, (*l)[start:start+deleteCount])
+
+	res := make(Array[T], 0, length-deleteCount+len(items))
+	res = append(res, (*l)[:start]...)
+	res = append(res, items...)
+	res = append(res, (*l)[start+deleteCount:]...)
+
+	*l = res
+
+	return
+}
 
 /*
 ToString return a string containing all values parsed to string.
@@ -555,6 +555,35 @@ func TestSlice(t *testing.T) {
 		}
 	})
 
+	t.Run("TestSplice", func(t *testing.T) {
+		t.Run("RemoveAndInsert", func(t *testing.T) {
+			a := arrayFuncs.Array[int]{1, 2, 3, 4, 5}
+
+			removed := a.Splice(1, 2, 10, 20, 30)
+
+			assert.Equal(t, arrayFuncs.Array[int]{2, 3}, removed)
+			assert.Equal(t, arrayFuncs.Array[int]{1, 10, 20, 30, 4, 5}, a)
+		})
+
+		t.Run("NegativeStart", func(t *testing.T) {
+			a := arrayFuncs.Array[int]{1, 2, 3, 4, 5}
+
+			removed := a.Splice(-2, 1)
+
+			assert.Equal(t, arrayFuncs.Array[int]{4}, removed)
+			assert.Equal(t, arrayFuncs.Array[int]{1, 2, 3, 5}, a)
+		})
+
+		t.Run("OutOfRange", func(t *testing.T) {
+			a := arrayFuncs.Array[int]{1, 2, 3}
+
+			removed := a.Splice(10, 10, 4)
+
+			assert.Equal(t, 0, len(removed))
+			assert.Equal(t, arrayFuncs.Array[int]{1, 2, 3, 4}, a)
+		})
+	})
+
 	t.Run("TestToString", func(t *testing.T) {
 		var (
 			a         = arrayFuncs.Array[int]{1, 2, 3, 4, 5}
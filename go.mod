@@ -1,8 +1,11 @@
 module github.com/izacgaldino23/array-funcs
 
-go 1.18
+go 1.23.0
 
-require github.com/stretchr/testify v1.8.1
+require (
+	github.com/stretchr/testify v1.8.1
+	golang.org/x/text v0.28.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
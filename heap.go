@@ -0,0 +1,288 @@
+package arrayfuncs
+
+import (
+	"container/heap"
+)
+
+// QueueHandle point to an element of a PriorityQueue, to update or remove it later
+type QueueHandle struct {
+	index int
+}
+
+// Valid return false when the element of the handle was already removed from the queue
+func (h *QueueHandle) Valid() bool {
+	return h != nil && h.index >= 0
+}
+
+/*
+PriorityQueue is a binary heap over an Array, where the first element according to the Comparator is on the top.
+Use Comparator.Reverse to have the greatest element on the top
+
+	q := NewPriorityQueue(Ascending(func(t Task) int { return t.Priority }))
+
+	handle := q.Push(task)
+	q.Update(handle, urgentTask)
+	next := q.Pop()
+
+➡ Heap return a heap.Interface over the same queue, so the container/heap functions can be used too
+*/
+type PriorityQueue[T comparable] struct {
+	items   Array[T]
+	handles []*QueueHandle
+	cmp     Comparator[T]
+}
+
+// NewPriorityQueue create a PriorityQueue ordered by cmp with the values, in O(n)
+func NewPriorityQueue[T comparable](cmp Comparator[T], values ...T) *PriorityQueue[T] {
+	q := &PriorityQueue[T]{
+		items:   make(Array[T], len(values)),
+		handles: make([]*QueueHandle, len(values)),
+		cmp:     cmp,
+	}
+
+	copy(q.items, values)
+
+	for i := range q.handles {
+		q.handles[i] = &QueueHandle{index: i}
+	}
+
+	heap.Init(q.Heap())
+
+	return q
+}
+
+// Len return the count of elements on the queue
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// Push add the value to the queue and return its handle, in O(log n)
+func (q *PriorityQueue[T]) Push(value T) *QueueHandle {
+	handle := &QueueHandle{index: len(q.items)}
+
+	q.items = append(q.items, value)
+	q.handles = append(q.handles, handle)
+
+	heap.Fix(q.Heap(), handle.index)
+
+	return handle
+}
+
+// Pop remove and return the top element, or nil if the queue is empty
+func (q *PriorityQueue[T]) Pop() (res *T) {
+	if len(q.items) == 0 {
+		return
+	}
+
+	value := heap.Pop(q.Heap()).(T)
+
+	return &value
+}
+
+// Peek return the top element without removing it, or nil if the queue is empty
+func (q *PriorityQueue[T]) Peek() (res *T) {
+	if len(q.items) == 0 {
+		return
+	}
+
+	value := q.items[0]
+
+	return &value
+}
+
+// Update replace the value of the handle and move it to its new position, return false if the handle isn't on the queue
+func (q *PriorityQueue[T]) Update(handle *QueueHandle, value T) bool {
+	if !q.owns(handle) {
+		return false
+	}
+
+	q.items[handle.index] = value
+	heap.Fix(q.Heap(), handle.index)
+
+	return true
+}
+
+// Remove remove the element of the handle and return it, or nil if the handle isn't on the queue
+func (q *PriorityQueue[T]) Remove(handle *QueueHandle) (res *T) {
+	if !q.owns(handle) {
+		return
+	}
+
+	value := heap.Remove(q.Heap(), handle.index).(T)
+
+	return &value
+}
+
+// Snapshot return a copy of the elements in the heap order
+func (q *PriorityQueue[T]) Snapshot() (res Array[T]) {
+	res = make(Array[T], len(q.items))
+	copy(res, q.items)
+
+	return
+}
+
+func (q *PriorityQueue[T]) owns(handle *QueueHandle) bool {
+	return handle.Valid() && handle.index < len(q.handles) && q.handles[handle.index] == handle
+}
+
+// Heap return the queue as a heap.Interface. Push receive a T and Pop return a T
+func (q *PriorityQueue[T]) Heap() heap.Interface {
+	return queueHeap[T]{q}
+}
+
+// queueHeap implement heap.Interface for the PriorityQueue, keeping the handles on their elements
+type queueHeap[T comparable] struct {
+	q *PriorityQueue[T]
+}
+
+func (h queueHeap[T]) Len() int { return len(h.q.items) }
+
+func (h queueHeap[T]) Less(i, j int) bool { return h.q.cmp(h.q.items[i], h.q.items[j]) < 0 }
+
+func (h queueHeap[T]) Swap(i, j int) {
+	q := h.q
+
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.handles[i], q.handles[j] = q.handles[j], q.handles[i]
+	q.handles[i].index = i
+	q.handles[j].index = j
+}
+
+func (h queueHeap[T]) Push(x any) {
+	q := h.q
+
+	q.items = append(q.items, x.(T))
+	q.handles = append(q.handles, &QueueHandle{index: len(q.handles)})
+}
+
+func (h queueHeap[T]) Pop() any {
+	var (
+		q     = h.q
+		last  = len(q.items) - 1
+		value = q.items[last]
+		zero  T
+	)
+
+	q.handles[last].index = -1
+	q.items[last] = zero
+	q.items, q.handles = q.items[:last], q.handles[:last]
+
+	return value
+}
+
+/*
+TopK return the k greatest elements according to cmp, from the greatest to the lowest, in O(n log k)
+
+	a := Array[int]{5, 1, 9, 3, 7}
+	a.TopK(2, Ascending(func(v int) int { return v })) // {9, 7}
+*/
+func (l *Array[T]) TopK(k int, cmp Comparator[T]) (res Array[T]) {
+	return l.selectK(k, cmp)
+}
+
+// BottomK return the k lowest elements according to cmp, from the lowest to the greatest, in O(n log k)
+func (l *Array[T]) BottomK(k int, cmp Comparator[T]) (res Array[T]) {
+	return l.selectK(k, cmp.Reverse())
+}
+
+// selectK keep the k greatest elements on a heap that has the lowest of them on the top
+func (l *Array[T]) selectK(k int, cmp Comparator[T]) (res Array[T]) {
+	if k <= 0 {
+		return Array[T]{}
+	}
+
+	if k > len(*l) {
+		k = len(*l)
+	}
+
+	q := NewPriorityQueue(cmp, (*l)[:k]...)
+	h := q.Heap()
+
+	for _, v := range (*l)[k:] {
+		if cmp(v, q.items[0]) > 0 {
+			q.items[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+
+	res = make(Array[T], len(q.items))
+
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = heap.Pop(h).(T)
+	}
+
+	return
+}
+
+/*
+NthElement reorder the Array so the element on index n is the one that would be there if the Array was sorted by cmp,
+with the lower elements before it and the greater after it. It runs in O(n) on average, with quickselect.
+It return the element or nil if the index is out of the Array, negative indexes count from the end
+
+	a := Array[int]{5, 1, 9, 3, 7}
+	a.NthElement(2, Ascending(func(v int) int { return v })) // 5
+*/
+func (l *Array[T]) NthElement(n int, cmp Comparator[T]) (res *T) {
+	items := *l
+
+	if n < 0 {
+		n += len(items)
+	}
+
+	if n < 0 || n >= len(items) {
+		return
+	}
+
+	low, high := 0, len(items)-1
+
+	for low < high {
+		pivot := medianOfThree(items, low, (low+high)/2, high, cmp)
+
+		i, j := low, high
+		for i <= j {
+			for cmp(items[i], pivot) < 0 {
+				i++
+			}
+
+			for cmp(items[j], pivot) > 0 {
+				j--
+			}
+
+			if i <= j {
+				items[i], items[j] = items[j], items[i]
+				i++
+				j--
+			}
+		}
+
+		// Continue only on the side that has the index n
+		switch {
+		case n <= j:
+			high = j
+		case n >= i:
+			low = i
+		default:
+			return &items[n]
+		}
+	}
+
+	return &items[n]
+}
+
+func medianOfThree[T comparable](items Array[T], a, b, c int, cmp Comparator[T]) T {
+	x, y, z := items[a], items[b], items[c]
+
+	if cmp(x, y) > 0 {
+		x, y = y, x
+	}
+
+	if cmp(y, z) > 0 {
+		y = z
+
+		if cmp(x, y) > 0 {
+			y = x
+		}
+	}
+
+	return y
+}
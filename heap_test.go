@@ -0,0 +1,134 @@
+package arrayfuncs_test
+
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func TestHeap(t *testing.T) {
+	ascending := arrayFuncs.Ascending(func(v int) int { return v })
+	byPriority := arrayFuncs.Ascending(func(v task) int { return v.priority })
+
+	drain := func(q *arrayFuncs.PriorityQueue[task]) (res []string) {
+		for q.Len() > 0 {
+			res = append(res, q.Pop().name)
+		}
+
+		return
+	}
+
+	t.Run("TopKAndBottomK", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{5, 1, 9, 3, 7, 9}
+
+		assert.Equal(t, arrayFuncs.Array[int]{9, 9, 7}, a.TopK(3, ascending))
+		assert.Equal(t, arrayFuncs.Array[int]{1, 3}, a.BottomK(2, ascending))
+		assert.Equal(t, arrayFuncs.Array[int]{9, 9, 7, 5, 3, 1}, a.TopK(10, ascending))
+		assert.Equal(t, arrayFuncs.Array[int]{}, a.TopK(0, ascending))
+		assert.Equal(t, arrayFuncs.Array[int]{5, 1, 9, 3, 7, 9}, a)
+	})
+
+	t.Run("TopKMatchesSort", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		a := make(arrayFuncs.Array[int], 1000)
+		for i := range a {
+			a[i] = random.Intn(500)
+		}
+
+		sorted := append(arrayFuncs.Array[int]{}, a...)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+		assert.Equal(t, sorted[:25], a.TopK(25, ascending))
+	})
+
+	t.Run("NthElement", func(t *testing.T) {
+		random := rand.New(rand.NewSource(2))
+
+		for _, size := range []int{1, 2, 3, 10, 101, 1000} {
+			a := make(arrayFuncs.Array[int], size)
+			for i := range a {
+				a[i] = random.Intn(size)
+			}
+
+			sorted := append(arrayFuncs.Array[int]{}, a...)
+			sort.Ints(sorted)
+
+			for _, n := range []int{0, size / 3, size / 2, size - 1} {
+				res := a.NthElement(n, ascending)
+
+				assert.Equal(t, sorted[n], *res)
+
+				for i := range a {
+					if i < n {
+						assert.LessOrEqual(t, a[i], a[n])
+					} else if i > n {
+						assert.GreaterOrEqual(t, a[i], a[n])
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("NthElementIndexes", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{5, 1, 9, 3, 7}
+
+		assert.Equal(t, 9, *a.NthElement(-1, ascending))
+		assert.Nil(t, a.NthElement(5, ascending))
+		assert.Nil(t, a.NthElement(-6, ascending))
+	})
+
+	t.Run("PriorityQueue", func(t *testing.T) {
+		q := arrayFuncs.NewPriorityQueue(byPriority, task{"c", 3}, task{"a", 1})
+
+		q.Push(task{"d", 4})
+		q.Push(task{"b", 2})
+
+		assert.Equal(t, 4, q.Len())
+		assert.Equal(t, "a", q.Peek().name)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, drain(q))
+		assert.Nil(t, q.Pop())
+		assert.Nil(t, q.Peek())
+	})
+
+	t.Run("PriorityQueueHandles", func(t *testing.T) {
+		q := arrayFuncs.NewPriorityQueue(byPriority.Reverse())
+
+		low := q.Push(task{"low", 1})
+		mid := q.Push(task{"mid", 5})
+		q.Push(task{"high", 9})
+
+		assert.True(t, q.Update(low, task{"low", 10}))
+		assert.Equal(t, "low", q.Peek().name)
+
+		assert.Equal(t, "mid", q.Remove(mid).name)
+		assert.False(t, mid.Valid())
+		assert.Nil(t, q.Remove(mid))
+		assert.False(t, q.Update(mid, task{"mid", 1}))
+
+		assert.Equal(t, []string{"low", "high"}, drain(q))
+		assert.False(t, low.Valid())
+	})
+
+	t.Run("ContainerHeap", func(t *testing.T) {
+		q := arrayFuncs.NewPriorityQueue(byPriority)
+		h := q.Heap()
+
+		heap.Push(h, task{"b", 2})
+		handle := q.Push(task{"c", 3})
+		heap.Push(h, task{"a", 1})
+
+		assert.Equal(t, task{"a", 1}, heap.Pop(h))
+		assert.True(t, q.Update(handle, task{"c", 0}))
+		assert.Equal(t, []string{"c", "b"}, drain(q))
+	})
+}
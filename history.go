@@ -0,0 +1,376 @@
+package arrayfuncs
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrCheckpointNotFound is returned when the checkpoint was never created or was discarded by a new change after an undo
+	ErrCheckpointNotFound = errors.New("arrayfuncs: checkpoint not found")
+	// ErrCheckpointExpired is returned when the changes needed to reach the checkpoint were dropped by the history limit
+	ErrCheckpointExpired = errors.New("arrayfuncs: checkpoint expired")
+)
+
+// historyEntry keep how to undo and redo one change
+type historyEntry[T comparable] struct {
+	undo func(a *Array[T])
+	redo func(a *Array[T])
+	cost int
+}
+
+/*
+HistoryArray is an Array that records every change, allowing undo and redo them
+
+	limit := 100
+	h := NewHistoryArray[int](limit, 1, 2, 3)
+
+	h.Push(4)
+	h.Checkpoint("before-reverse")
+	h.Reverse()
+
+	h.Undo()                        // Array is {1, 2, 3, 4}
+	h.Redo()                        // Array is {4, 3, 2, 1}
+	h.RestoreTo("before-reverse")   // Array is {1, 2, 3, 4}
+
+➡ The limit is the maximum count of elements kept by the history, when exceeded the oldest changes are dropped.
+If the limit is 0 the history is unbounded
+*/
+type HistoryArray[T comparable] struct {
+	items   Array[T]
+	entries []historyEntry[T]
+	limit   int
+	cost    int
+
+	// cursor is the count of applied entries, entries after it can be redone
+	cursor int
+	// dropped is the count of entries removed by the limit, used to keep the checkpoints absolute
+	dropped     int
+	checkpoints map[string]int
+}
+
+// NewHistoryArray create a HistoryArray with the limit of the history, 0 to keep everything, and the initial values
+func NewHistoryArray[T comparable](limit int, values ...T) *HistoryArray[T] {
+	return &HistoryArray[T]{
+		items:       AnyToArrayKind(values),
+		limit:       limit,
+		checkpoints: make(map[string]int),
+	}
+}
+
+// Len return the elements count
+func (h *HistoryArray[T]) Len() int {
+	return len(h.items)
+}
+
+// At return an element based on index, like Array.At
+func (h *HistoryArray[T]) At(index int) *T {
+	return h.items.At(index)
+}
+
+// Snapshot return a copy of the current elements
+func (h *HistoryArray[T]) Snapshot() Array[T] {
+	return AnyToArrayKind(h.items)
+}
+
+// CanUndo return true if there is a change to undo
+func (h *HistoryArray[T]) CanUndo() bool {
+	return h.cursor > 0
+}
+
+// CanRedo return true if there is an undone change to redo
+func (h *HistoryArray[T]) CanRedo() bool {
+	return h.cursor < len(h.entries)
+}
+
+// Undo revert the last change, return false if there is nothing to undo
+func (h *HistoryArray[T]) Undo() bool {
+	if !h.CanUndo() {
+		return false
+	}
+
+	h.cursor--
+	h.entries[h.cursor].undo(&h.items)
+
+	return true
+}
+
+// Redo apply again the last undone change, return false if there is nothing to redo
+func (h *HistoryArray[T]) Redo() bool {
+	if !h.CanRedo() {
+		return false
+	}
+
+	h.entries[h.cursor].redo(&h.items)
+	h.cursor++
+
+	return true
+}
+
+// Checkpoint save the current state with a name, an existing checkpoint with the same name is replaced
+func (h *HistoryArray[T]) Checkpoint(name string) {
+	h.checkpoints[name] = h.dropped + h.cursor
+}
+
+// RestoreTo undo or redo the changes until the state saved by the checkpoint
+func (h *HistoryArray[T]) RestoreTo(name string) error {
+	position, ok := h.checkpoints[name]
+	if !ok {
+		return ErrCheckpointNotFound
+	}
+
+	position -= h.dropped
+	if position < 0 {
+		return ErrCheckpointExpired
+	}
+
+	for h.cursor > position {
+		h.Undo()
+	}
+
+	for h.cursor < position {
+		h.Redo()
+	}
+
+	return nil
+}
+
+// Push add one or more elements to the end of the array
+func (h *HistoryArray[T]) Push(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+
+	values = AnyToArrayKind(values)
+
+	h.apply(historyEntry[T]{
+		undo: func(a *Array[T]) { *a = (*a)[:len(*a)-len(values)] },
+		redo: func(a *Array[T]) { a.Push(values...) },
+		cost: len(values),
+	})
+}
+
+// Pop remove the last element from this array, and return it.
+// If the array is empty return nil
+func (h *HistoryArray[T]) Pop() (res *T) {
+	if len(h.items) == 0 {
+		return
+	}
+
+	value := h.items[len(h.items)-1]
+	res = &value
+
+	h.apply(historyEntry[T]{
+		undo: func(a *Array[T]) { a.Push(value) },
+		redo: func(a *Array[T]) { a.Pop() },
+		cost: 1,
+	})
+
+	return
+}
+
+// Shift remove the first element from this array, and return it.
+// If the array is empty return nil
+func (h *HistoryArray[T]) Shift() (res *T) {
+	if len(h.items) == 0 {
+		return
+	}
+
+	value := h.items[0]
+	res = &value
+
+	h.apply(historyEntry[T]{
+		undo: func(a *Array[T]) { a.Unshift(value) },
+		redo: func(a *Array[T]) { a.Shift() },
+		cost: 1,
+	})
+
+	return
+}
+
+// Unshift add elements to the array init
+func (h *HistoryArray[T]) Unshift(values ...T) (newLength int) {
+	if len(values) == 0 {
+		return len(h.items)
+	}
+
+	values = AnyToArrayKind(values)
+
+	h.apply(historyEntry[T]{
+		undo: func(a *Array[T]) { a.Splice(0, len(values)) },
+		redo: func(a *Array[T]) { a.Unshift(values...) },
+		cost: len(values),
+	})
+
+	return len(h.items)
+}
+
+// Splice remove 'deleteCount' elements starting at 'start' and insert the items in their place, like Array.Splice
+func (h *HistoryArray[T]) Splice(start, deleteCount int, items ...T) (removed Array[T]) {
+	items = AnyToArrayKind(items)
+
+	index := start
+	if index < 0 {
+		index += len(h.items)
+
+		if index < 0 {
+			index = 0
+		}
+	} else if index > len(h.items) {
+		index = len(h.items)
+	}
+
+	if deleteCount < 0 {
+		deleteCount = 0
+	} else if deleteCount > len(h.items)-index {
+		deleteCount = len(h.items) - index
+	}
+
+	removed = AnyToArrayKind(h.items[index : index+deleteCount])
+
+	if len(removed) == 0 && len(items) == 0 {
+		return
+	}
+
+	saved := AnyToArrayKind(removed)
+
+	h.apply(historyEntry[T]{
+		undo: func(a *Array[T]) { a.Splice(index, len(items), saved...) },
+		redo: func(a *Array[T]) { a.Splice(index, len(saved), items...) },
+		cost: len(items) + len(saved),
+	})
+
+	return
+}
+
+// Fill set the value from start to end, like Array.Fill
+func (h *HistoryArray[T]) Fill(value T, start int, end ...int) {
+	changed := h.Snapshot()
+	changed.Fill(value, start, end...)
+
+	h.applyUpdates(changed)
+}
+
+// Map iterate all elements with a callback function that can change the original value
+func (h *HistoryArray[T]) Map(callback func(v *T, i int)) {
+	changed := h.Snapshot()
+	changed.Map(callback)
+
+	h.applyUpdates(changed)
+}
+
+// Reverse reverses the order of the elements
+func (h *HistoryArray[T]) Reverse() {
+	h.apply(historyEntry[T]{
+		undo: func(a *Array[T]) { a.Reverse() },
+		redo: func(a *Array[T]) { a.Reverse() },
+		cost: 1,
+	})
+}
+
+/*
+Sort sorts the array based on comparable function callback passing the index of the current element and the next element,
+like Array.Sort. Use At to read the elements inside the callback
+*/
+func (h *HistoryArray[T]) Sort(callback func(index1, index2 int) bool) {
+	s := &permutationSorter[T]{items: h.items, positions: h.items.Keys(), less: callback}
+
+	sort.Stable(s)
+
+	positions := s.positions
+
+	h.record(historyEntry[T]{
+		undo: func(a *Array[T]) {
+			old := make(Array[T], len(*a))
+			for i, position := range positions {
+				old[position] = (*a)[i]
+			}
+
+			*a = old
+		},
+		redo: func(a *Array[T]) {
+			sorted := make(Array[T], len(*a))
+			for i, position := range positions {
+				sorted[i] = (*a)[position]
+			}
+
+			*a = sorted
+		},
+		cost: len(positions),
+	})
+}
+
+// applyUpdates record the elements that are different on changed as one change
+func (h *HistoryArray[T]) applyUpdates(changed Array[T]) {
+	var (
+		indexes   []int
+		newValues Array[T]
+		oldValues Array[T]
+	)
+
+	for i := range changed {
+		if changed[i] != h.items[i] {
+			indexes = append(indexes, i)
+			newValues = append(newValues, changed[i])
+			oldValues = append(oldValues, h.items[i])
+		}
+	}
+
+	if len(indexes) == 0 {
+		return
+	}
+
+	set := func(values Array[T]) func(a *Array[T]) {
+		return func(a *Array[T]) {
+			for j, index := range indexes {
+				(*a)[index] = values[j]
+			}
+		}
+	}
+
+	h.apply(historyEntry[T]{
+		undo: set(oldValues),
+		redo: set(newValues),
+		cost: len(indexes) * 2,
+	})
+}
+
+// apply execute the change and record it
+func (h *HistoryArray[T]) apply(entry historyEntry[T]) {
+	entry.redo(&h.items)
+	h.record(entry)
+}
+
+// record save an already applied change, discarding the undone ones and the oldest over the limit
+func (h *HistoryArray[T]) record(entry historyEntry[T]) {
+	for _, discarded := range h.entries[h.cursor:] {
+		h.cost -= discarded.cost
+	}
+
+	h.entries = append(h.entries[:h.cursor], entry)
+	h.cursor++
+	h.cost += entry.cost
+
+	// Checkpoints after the cursor can't be reached anymore
+	for name, position := range h.checkpoints {
+		if position-h.dropped >= h.cursor {
+			delete(h.checkpoints, name)
+		}
+	}
+
+	if h.limit <= 0 {
+		return
+	}
+
+	drop := 0
+	for h.cost > h.limit && drop < len(h.entries)-1 {
+		h.cost -= h.entries[drop].cost
+		drop++
+	}
+
+	if drop > 0 {
+		h.entries = append(h.entries[:0:0], h.entries[drop:]...)
+		h.cursor -= drop
+		h.dropped += drop
+	}
+}
@@ -0,0 +1,111 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryArray(t *testing.T) {
+
+	t.Run("UndoRedoEachMethod", func(t *testing.T) {
+		h := arrayFuncs.NewHistoryArray(0, 5, 3, 1, 4)
+
+		states := []arrayFuncs.Array[int]{h.Snapshot()}
+		save := func() {
+			states = append(states, h.Snapshot())
+		}
+
+		h.Push(9, 8)
+		save()
+		h.Pop()
+		save()
+		h.Shift()
+		save()
+		h.Unshift(7, 6)
+		save()
+		h.Fill(0, 1, 2)
+		save()
+		h.Reverse()
+		save()
+		h.Sort(func(index1, index2 int) bool {
+			return *h.At(index1) < *h.At(index2)
+		})
+		save()
+		h.Splice(-3, 2, 10, 11, 12)
+		save()
+		h.Map(func(v *int, i int) {
+			*v = *v * 2
+		})
+		save()
+
+		// Go back until the initial state
+		for i := len(states) - 2; i >= 0; i-- {
+			assert.True(t, h.Undo())
+			assert.Equal(t, states[i], h.Snapshot())
+		}
+
+		assert.False(t, h.Undo())
+
+		// And forward until the last state
+		for i := 1; i < len(states); i++ {
+			assert.True(t, h.Redo())
+			assert.Equal(t, states[i], h.Snapshot())
+		}
+
+		assert.False(t, h.Redo())
+	})
+
+	t.Run("NewChangeDiscardRedo", func(t *testing.T) {
+		h := arrayFuncs.NewHistoryArray(0, 1, 2)
+
+		h.Push(3)
+		h.Undo()
+		h.Push(4)
+
+		assert.False(t, h.CanRedo())
+		assert.Equal(t, arrayFuncs.Array[int]{1, 2, 4}, h.Snapshot())
+	})
+
+	t.Run("Checkpoints", func(t *testing.T) {
+		h := arrayFuncs.NewHistoryArray(0, 1, 2, 3)
+
+		h.Push(4)
+		h.Checkpoint("pushed")
+		h.Reverse()
+		h.Shift()
+		h.Checkpoint("shifted")
+
+		assert.Nil(t, h.RestoreTo("pushed"))
+		assert.Equal(t, arrayFuncs.Array[int]{1, 2, 3, 4}, h.Snapshot())
+
+		assert.Nil(t, h.RestoreTo("shifted"))
+		assert.Equal(t, arrayFuncs.Array[int]{3, 2, 1}, h.Snapshot())
+
+		assert.ErrorIs(t, h.RestoreTo("unknown"), arrayFuncs.ErrCheckpointNotFound)
+
+		// A new change after going back discards the checkpoints ahead
+		assert.Nil(t, h.RestoreTo("pushed"))
+		h.Pop()
+
+		assert.ErrorIs(t, h.RestoreTo("shifted"), arrayFuncs.ErrCheckpointNotFound)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		h := arrayFuncs.NewHistoryArray[int](3)
+
+		h.Checkpoint("empty")
+		h.Push(1)
+		h.Push(2)
+		h.Push(3)
+		h.Push(4)
+
+		// Only the last 3 pushes are kept
+		for h.Undo() {
+		}
+
+		assert.Equal(t, arrayFuncs.Array[int]{1}, h.Snapshot())
+		assert.ErrorIs(t, h.RestoreTo("empty"), arrayFuncs.ErrCheckpointExpired)
+	})
+}
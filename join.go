@@ -0,0 +1,148 @@
+package arrayfuncs
+
+/*
+JoinPair is one row of a join between two Arrays.
+On outer joins the side without a match is nil
+*/
+type JoinPair[L, R comparable] struct {
+	Left  *L
+	Right *R
+}
+
+/*
+InnerJoin return the pairs of elements of both Arrays with the same key, using a hash of the right side.
+The pairs follow the left order, and the right order when one left element matches many right ones
+
+	orders := Array[Order]{{ID: 1, CustomerID: 10}}
+	customers := Array[Customer]{{ID: 10, Name: "ann"}}
+
+	pairs := InnerJoin(orders, customers,
+		func(o Order) int { return o.CustomerID },
+		func(c Customer) int { return c.ID },
+	)
+
+	// pairs[0].Left is the order and pairs[0].Right is the customer
+*/
+func InnerJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K) (res Array[JoinPair[L, R]]) {
+	res, _ = hashJoin(left, right, leftKey, rightKey, false)
+
+	return
+}
+
+// LeftJoin return the pairs like InnerJoin, plus the left elements without match, with a nil Right
+func LeftJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K) (res Array[JoinPair[L, R]]) {
+	res, _ = hashJoin(left, right, leftKey, rightKey, true)
+
+	return
+}
+
+// RightJoin return the pairs like InnerJoin, plus the right elements without match, with a nil Left. The pairs follow the right order
+func RightJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K) (res Array[JoinPair[L, R]]) {
+	swapped, _ := hashJoin(right, left, rightKey, leftKey, true)
+
+	res = make(Array[JoinPair[L, R]], len(swapped))
+
+	for i := range swapped {
+		res[i] = JoinPair[L, R]{Left: swapped[i].Right, Right: swapped[i].Left}
+	}
+
+	return
+}
+
+// FullOuterJoin return the pairs like LeftJoin, followed by the right elements without match
+func FullOuterJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K) (res Array[JoinPair[L, R]]) {
+	res, matched := hashJoin(left, right, leftKey, rightKey, true)
+
+	for i := range right {
+		if !matched[i] {
+			res = append(res, JoinPair[L, R]{Right: &right[i]})
+		}
+	}
+
+	return
+}
+
+// SemiJoin return the left elements that have at least one match on the right
+func SemiJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K) (res Array[L]) {
+	keys := keySet(right, rightKey)
+
+	for i := range left {
+		if keys[leftKey(left[i])] {
+			res = append(res, left[i])
+		}
+	}
+
+	return
+}
+
+// AntiJoin return the left elements that don't have any match on the right
+func AntiJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K) (res Array[L]) {
+	keys := keySet(right, rightKey)
+
+	for i := range left {
+		if !keys[leftKey(left[i])] {
+			res = append(res, left[i])
+		}
+	}
+
+	return
+}
+
+/*
+Project convert the pairs of a join with the merge function
+
+	rows := Project(LeftJoin(orders, customers, orderCustomer, customerID), func(o *Order, c *Customer) Row {
+		row := Row{OrderID: o.ID}
+
+		if c != nil {
+			row.CustomerName = c.Name
+		}
+
+		return row
+	})
+*/
+func Project[L, R, V comparable](pairs Array[JoinPair[L, R]], merge func(left *L, right *R) V) (res Array[V]) {
+	res = make(Array[V], len(pairs))
+
+	for i := range pairs {
+		res[i] = merge(pairs[i].Left, pairs[i].Right)
+	}
+
+	return
+}
+
+// hashJoin join the left elements with the right ones and return which right elements had a match
+func hashJoin[L, R, K comparable](left Array[L], right Array[R], leftKey func(v L) K, rightKey func(v R) K, keepLeft bool) (res Array[JoinPair[L, R]], matched []bool) {
+	index := make(map[K][]int, len(right))
+	matched = make([]bool, len(right))
+
+	for i := range right {
+		k := rightKey(right[i])
+		index[k] = append(index[k], i)
+	}
+
+	for i := range left {
+		matches := index[leftKey(left[i])]
+
+		if len(matches) == 0 && keepLeft {
+			res = append(res, JoinPair[L, R]{Left: &left[i]})
+		}
+
+		for _, j := range matches {
+			matched[j] = true
+			res = append(res, JoinPair[L, R]{Left: &left[i], Right: &right[j]})
+		}
+	}
+
+	return
+}
+
+func keySet[T, K comparable](list Array[T], key func(v T) K) map[K]bool {
+	res := make(map[K]bool, len(list))
+
+	for i := range list {
+		res[key(list[i])] = true
+	}
+
+	return res
+}
@@ -0,0 +1,87 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type order struct {
+	id         int
+	customerID int
+}
+
+type customer struct {
+	id   int
+	name string
+}
+
+func TestJoins(t *testing.T) {
+	var (
+		orders = arrayFuncs.Array[order]{{1, 10}, {2, 20}, {3, 10}, {4, 99}}
+
+		customers = arrayFuncs.Array[customer]{{10, "ann"}, {20, "bob"}, {30, "carl"}}
+
+		orderKey    = func(o order) int { return o.customerID }
+		customerKey = func(c customer) int { return c.id }
+	)
+
+	// describe convert the pairs to "order:customer" to make the comparison easier
+	describe := func(pairs arrayFuncs.Array[arrayFuncs.JoinPair[order, customer]]) []string {
+		rows := arrayFuncs.Project(pairs, func(o *order, c *customer) string {
+			var (
+				left  = "-"
+				right = "-"
+			)
+
+			if o != nil {
+				left = arrayFuncs.AnyToString(o.id)
+			}
+
+			if c != nil {
+				right = c.name
+			}
+
+			return left + ":" + right
+		})
+
+		return rows.ToOriginalKind()
+	}
+
+	t.Run("InnerJoin", func(t *testing.T) {
+		pairs := arrayFuncs.InnerJoin(orders, customers, orderKey, customerKey)
+
+		assert.Equal(t, []string{"1:ann", "2:bob", "3:ann"}, describe(pairs))
+	})
+
+	t.Run("LeftJoin", func(t *testing.T) {
+		pairs := arrayFuncs.LeftJoin(orders, customers, orderKey, customerKey)
+
+		assert.Equal(t, []string{"1:ann", "2:bob", "3:ann", "4:-"}, describe(pairs))
+	})
+
+	t.Run("RightJoin", func(t *testing.T) {
+		pairs := arrayFuncs.RightJoin(orders, customers, orderKey, customerKey)
+
+		assert.Equal(t, []string{"1:ann", "3:ann", "2:bob", "-:carl"}, describe(pairs))
+	})
+
+	t.Run("FullOuterJoin", func(t *testing.T) {
+		pairs := arrayFuncs.FullOuterJoin(orders, customers, orderKey, customerKey)
+
+		assert.Equal(t, []string{"1:ann", "2:bob", "3:ann", "4:-", "-:carl"}, describe(pairs))
+	})
+
+	t.Run("SemiJoin", func(t *testing.T) {
+		res := arrayFuncs.SemiJoin(customers, orders, customerKey, orderKey)
+
+		assert.Equal(t, arrayFuncs.Array[customer]{{10, "ann"}, {20, "bob"}}, res)
+	})
+
+	t.Run("AntiJoin", func(t *testing.T) {
+		res := arrayFuncs.AntiJoin(orders, customers, orderKey, customerKey)
+
+		assert.Equal(t, arrayFuncs.Array[order]{{4, 99}}, res)
+	})
+}
@@ -0,0 +1,193 @@
+package arrayfuncs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The lexer is shared by Query and CompilePredicate, so both have the same rules for names, numbers and strings
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenComma
+	tokenDot
+	tokenStar
+	tokenLeftParen
+	tokenRightParen
+	tokenLeftBracket
+	tokenRightBracket
+)
+
+// token is a part of the input, the text of a string token is its value, without the quotes and escapes
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t token) is(keyword string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.text, keyword)
+}
+
+// lexRules are the differences between the languages that use the lexer
+type lexRules struct {
+	// identRune test if the rune can continue a name
+	identRune func(r rune) bool
+	// signedNumbers read a '-' followed by a digit as part of the number
+	signedNumbers bool
+	// operatorStart are the characters that start an operator, and operatorNext the ones that can be its second character
+	operatorStart string
+	operatorNext  string
+	// punctuation is the kind of the other tokens with one character
+	punctuation map[rune]tokenKind
+}
+
+// lexError is an invalid input, each language convert it to its own error type
+type lexError struct {
+	line    int
+	column  int
+	message string
+}
+
+// lex split the input on tokens, always ending with a tokenEOF
+func lex(input string, rules lexRules) (tokens []token, err *lexError) {
+	var (
+		runes  = []rune(input)
+		line   = 1
+		column = 1
+	)
+
+	for i := 0; i < len(runes); {
+		var (
+			r     = runes[i]
+			start = token{line: line, column: column}
+			size  = 1
+		)
+
+		switch {
+		case unicode.IsSpace(r):
+			start.kind = -1
+		case unicode.IsLetter(r) || r == '_':
+			for i+size < len(runes) && rules.identRune(runes[i+size]) {
+				size++
+			}
+
+			start.kind = tokenIdent
+		case unicode.IsDigit(r) || (rules.signedNumbers && r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			for i+size < len(runes) && (unicode.IsDigit(runes[i+size]) || runes[i+size] == '.') {
+				size++
+			}
+
+			start.kind = tokenNumber
+		case r == '\'' || r == '"':
+			text, read, message := scanQuoted(runes[i:])
+			if message != "" {
+				return nil, &lexError{line, column, message}
+			}
+
+			start.kind = tokenString
+			start.text = text
+			size = read
+		case strings.ContainsRune(rules.operatorStart, r):
+			if i+1 < len(runes) && strings.ContainsRune(rules.operatorNext, runes[i+1]) {
+				size++
+			}
+
+			start.kind = tokenOperator
+		default:
+			kind, ok := rules.punctuation[r]
+			if !ok {
+				return nil, &lexError{line, column, fmt.Sprintf("unexpected character %q", r)}
+			}
+
+			start.kind = kind
+		}
+
+		if start.kind != tokenString {
+			start.text = string(runes[i : i+size])
+		}
+
+		for j := 0; j < size; j++ {
+			if runes[i] == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+
+			i++
+		}
+
+		if start.kind >= 0 {
+			tokens = append(tokens, start)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, line: line, column: column})
+
+	return
+}
+
+/*
+scanQuoted read the string that starts with a quote, returning its value and the count of runes read.
+A quote inside the string is written twice like SQL or escaped with a backslash,
+and the other backslash escapes are the same as Go
+
+	'it''s'  'it\'s'  "say ""hi"""  'line\n'
+*/
+func scanQuoted(runes []rune) (text string, size int, message string) {
+	var (
+		quote = runes[0]
+		// literal is the string as a Go double quoted literal, decoded by strconv.Unquote
+		literal strings.Builder
+	)
+
+	literal.WriteByte('"')
+
+	for size = 1; size < len(runes); size++ {
+		switch r := runes[size]; {
+		case r == '\\' && size+1 < len(runes):
+			size++
+
+			if runes[size] != '\'' {
+				literal.WriteByte('\\')
+			}
+
+			literal.WriteRune(runes[size])
+		case r == quote && size+1 < len(runes) && runes[size+1] == quote:
+			size++
+
+			if quote == '"' {
+				literal.WriteString(`\"`)
+			} else {
+				literal.WriteRune(quote)
+			}
+		case r == quote:
+			literal.WriteByte('"')
+
+			value, err := strconv.Unquote(literal.String())
+			if err != nil {
+				return "", 0, fmt.Sprintf("invalid string %s", string(runes[:size+1]))
+			}
+
+			return value, size + 1, ""
+		case r == '"':
+			literal.WriteString(`\"`)
+		case r == '\n':
+			literal.WriteString(`\n`)
+		default:
+			literal.WriteRune(r)
+		}
+	}
+
+	return "", 0, "unterminated string"
+}
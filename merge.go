@@ -0,0 +1,272 @@
+package arrayfuncs
+
+// ConflictKind is the reason why both sides of a merge couldn't be applied
+type ConflictKind string
+
+const (
+	// ConflictModify means both sides changed the same element in different ways
+	ConflictModify ConflictKind = "modify"
+	// ConflictDeleteModify means one side deleted the element and the other changed it
+	ConflictDeleteModify ConflictKind = "delete-modify"
+	// ConflictAdd means both sides added different elements with the same key
+	ConflictAdd ConflictKind = "add"
+)
+
+/*
+Conflict describe an element changed differently by both sides of a merge.
+The Base, Ours and Theirs are nil when the element doesn't exist on that side.
+Index is the position of the element on the merged Array
+*/
+type Conflict[T, K comparable] struct {
+	Kind   ConflictKind
+	Key    K
+	Base   *T
+	Ours   *T
+	Theirs *T
+	Index  int
+}
+
+/*
+ConflictResolver decide the value of a conflicting element.
+It returns the value to keep, or nil to remove the element, and false when it can't resolve the conflict
+*/
+type ConflictResolver[T, K comparable] func(conflict Conflict[T, K]) (value *T, resolved bool)
+
+// MergeResult is the result of Merge3, with the merged Array and the unresolved conflicts
+type MergeResult[T, K comparable] struct {
+	Merged    Array[T]
+	Conflicts []Conflict[T, K]
+}
+
+// PreferOurs is a ConflictResolver that always keep our side
+func PreferOurs[T, K comparable](conflict Conflict[T, K]) (*T, bool) {
+	return conflict.Ours, true
+}
+
+// PreferTheirs is a ConflictResolver that always keep their side
+func PreferTheirs[T, K comparable](conflict Conflict[T, K]) (*T, bool) {
+	return conflict.Theirs, true
+}
+
+/*
+Merge3 merge the changes made by ours and theirs over the same base, identifying the elements by the key.
+
+	base := Array[Setting]{{"a", 1}, {"b", 2}}
+	ours := Array[Setting]{{"a", 10}, {"b", 2}}
+	theirs := Array[Setting]{{"b", 2}, {"c", 3}}
+
+	result := Merge3(base, ours, theirs, func(s Setting) string { return s.Name })
+
+	// result.Merged is {{"a", 10}, {"b", 2}, {"c", 3}} and result.Conflicts is empty
+
+➡ The order of ours is kept, and the elements added only by theirs are placed after the element they follow on theirs.
+When a conflict isn't resolved the changed value is kept, preferring ours, and the conflict is reported.
+The keys must be unique in each Array
+*/
+func Merge3[T, K comparable](base, ours, theirs Array[T], key func(v T) K, resolver ...ConflictResolver[T, K]) (res MergeResult[T, K]) {
+	var (
+		baseValues   = indexByKey(base, key)
+		oursValues   = indexByKey(ours, key)
+		theirsValues = indexByKey(theirs, key)
+		values       = make(map[K]T)
+		decided      = make(map[K]bool)
+		conflicts    = make(map[K]*Conflict[T, K])
+	)
+
+	decide := func(k K) {
+		var (
+			b, inBase   = baseValues[k]
+			o, inOurs   = oursValues[k]
+			t, inTheirs = theirsValues[k]
+		)
+
+		switch {
+		case inOurs && inTheirs && o == t:
+			values[k] = o
+			return
+		case inBase && inOurs && o == b:
+			// Only theirs changed
+			if inTheirs {
+				values[k] = t
+			}
+
+			return
+		case inBase && inTheirs && t == b:
+			// Only ours changed
+			if inOurs {
+				values[k] = o
+			}
+
+			return
+		case inBase && !inOurs && !inTheirs:
+			return
+		case !inBase && inOurs != inTheirs:
+			if inOurs {
+				values[k] = o
+			} else {
+				values[k] = t
+			}
+
+			return
+		}
+
+		conflict := &Conflict[T, K]{Kind: ConflictModify, Key: k}
+
+		if inBase {
+			conflict.Base = &b
+
+			if !inOurs || !inTheirs {
+				conflict.Kind = ConflictDeleteModify
+			}
+		} else {
+			conflict.Kind = ConflictAdd
+		}
+
+		if inOurs {
+			conflict.Ours = &o
+		}
+
+		if inTheirs {
+			conflict.Theirs = &t
+		}
+
+		if len(resolver) > 0 && resolver[0] != nil {
+			if value, resolved := resolver[0](*conflict); resolved {
+				if value != nil {
+					values[k] = *value
+				}
+
+				return
+			}
+		}
+
+		if inOurs {
+			values[k] = o
+		} else {
+			values[k] = t
+		}
+
+		conflicts[k] = conflict
+	}
+
+	for _, list := range []Array[T]{ours, theirs, base} {
+		for i := range list {
+			k := key(list[i])
+
+			if !decided[k] {
+				decided[k] = true
+				decide(k)
+			}
+		}
+	}
+
+	// Order by ours, then place the elements that only exist in theirs after their predecessor
+	order := make([]K, 0, len(values))
+	placed := make(map[K]bool)
+
+	for i := range ours {
+		k := key(ours[i])
+
+		if _, ok := values[k]; ok && !placed[k] {
+			order = append(order, k)
+			placed[k] = true
+		}
+	}
+
+	position := 0
+
+	for i := range theirs {
+		k := key(theirs[i])
+
+		if placed[k] {
+			for j := range order {
+				if order[j] == k {
+					position = j + 1
+					break
+				}
+			}
+
+			continue
+		}
+
+		if _, ok := values[k]; !ok {
+			continue
+		}
+
+		order = append(order[:position], append([]K{k}, order[position:]...)...)
+		placed[k] = true
+		position++
+	}
+
+	res.Merged = make(Array[T], len(order))
+
+	for i, k := range order {
+		res.Merged[i] = values[k]
+
+		if conflict := conflicts[k]; conflict != nil {
+			conflict.Index = i
+			res.Conflicts = append(res.Conflicts, *conflict)
+		}
+	}
+
+	return
+}
+
+/*
+Merge3Strings merge string arrays like Merge3, but each unresolved conflict is replaced by conflict markers
+
+	<<<<<<< ours
+	name=ours value
+	||||||| base
+	name=base value
+	=======
+	name=their value
+	>>>>>>> theirs
+*/
+func Merge3Strings[K comparable](base, ours, theirs Array[string], key func(v string) K, resolver ...ConflictResolver[string, K]) (res Array[string], conflicts []Conflict[string, K]) {
+	result := Merge3(base, ours, theirs, key, resolver...)
+	conflicts = result.Conflicts
+
+	next := 0
+
+	for i := range result.Merged {
+		if next < len(conflicts) && conflicts[next].Index == i {
+			res.Push(ConflictMarkers(conflicts[next])...)
+			next++
+
+			continue
+		}
+
+		res.Push(result.Merged[i])
+	}
+
+	return
+}
+
+// ConflictMarkers return the lines describing the conflict with markers, the missing sides are empty
+func ConflictMarkers[K comparable](conflict Conflict[string, K]) (res Array[string]) {
+	section := func(marker string, value *string) {
+		res.Push(marker)
+
+		if value != nil {
+			res.Push(*value)
+		}
+	}
+
+	section("<<<<<<< ours", conflict.Ours)
+	section("||||||| base", conflict.Base)
+	section("=======", conflict.Theirs)
+	res.Push(">>>>>>> theirs")
+
+	return
+}
+
+func indexByKey[T, K comparable](list Array[T], key func(v T) K) map[K]T {
+	res := make(map[K]T, len(list))
+
+	for i := range list {
+		res[key(list[i])] = list[i]
+	}
+
+	return res
+}
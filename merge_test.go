@@ -0,0 +1,91 @@
+package arrayfuncs_test
+
+import (
+	"strings"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type setting struct {
+	name  string
+	value int
+}
+
+func settingName(s setting) string {
+	return s.name
+}
+
+func TestMerge3(t *testing.T) {
+
+	t.Run("NoConflicts", func(t *testing.T) {
+		var (
+			base   = arrayFuncs.Array[setting]{{"a", 1}, {"b", 2}, {"c", 3}}
+			ours   = arrayFuncs.Array[setting]{{"a", 10}, {"b", 2}, {"c", 3}, {"d", 4}}
+			theirs = arrayFuncs.Array[setting]{{"a", 1}, {"x", 9}, {"b", 20}}
+		)
+
+		result := arrayFuncs.Merge3(base, ours, theirs, settingName)
+
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, arrayFuncs.Array[setting]{{"a", 10}, {"x", 9}, {"b", 20}, {"d", 4}}, result.Merged)
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		var (
+			base   = arrayFuncs.Array[setting]{{"a", 1}, {"b", 2}}
+			ours   = arrayFuncs.Array[setting]{{"a", 10}, {"c", 3}}
+			theirs = arrayFuncs.Array[setting]{{"a", 11}, {"b", 22}, {"c", 4}}
+		)
+
+		result := arrayFuncs.Merge3(base, ours, theirs, settingName)
+
+		assert.Equal(t, arrayFuncs.Array[setting]{{"a", 10}, {"b", 22}, {"c", 3}}, result.Merged)
+		assert.Equal(t, []arrayFuncs.Conflict[setting, string]{
+			{Kind: arrayFuncs.ConflictModify, Key: "a", Base: &base[0], Ours: &ours[0], Theirs: &theirs[0], Index: 0},
+			{Kind: arrayFuncs.ConflictDeleteModify, Key: "b", Base: &base[1], Theirs: &theirs[1], Index: 1},
+			{Kind: arrayFuncs.ConflictAdd, Key: "c", Ours: &ours[1], Theirs: &theirs[2], Index: 2},
+		}, result.Conflicts)
+	})
+
+	t.Run("Resolver", func(t *testing.T) {
+		var (
+			base   = arrayFuncs.Array[setting]{{"a", 1}, {"b", 2}}
+			ours   = arrayFuncs.Array[setting]{{"a", 10}}
+			theirs = arrayFuncs.Array[setting]{{"a", 11}, {"b", 22}}
+		)
+
+		result := arrayFuncs.Merge3(base, ours, theirs, settingName, arrayFuncs.PreferTheirs[setting, string])
+
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, arrayFuncs.Array[setting]{{"a", 11}, {"b", 22}}, result.Merged)
+
+		result = arrayFuncs.Merge3(base, ours, theirs, settingName, arrayFuncs.PreferOurs[setting, string])
+
+		assert.Equal(t, arrayFuncs.Array[setting]{{"a", 10}}, result.Merged)
+	})
+
+	t.Run("ConflictMarkers", func(t *testing.T) {
+		var (
+			base   = arrayFuncs.Array[string]{"host=a", "port=1"}
+			ours   = arrayFuncs.Array[string]{"host=b", "port=1"}
+			theirs = arrayFuncs.Array[string]{"host=c", "port=2"}
+			key    = func(v string) string { return strings.Split(v, "=")[0] }
+		)
+
+		merged, conflicts := arrayFuncs.Merge3Strings(base, ours, theirs, key)
+
+		assert.Equal(t, 1, len(conflicts))
+		assert.Equal(t, arrayFuncs.Array[string]{
+			"<<<<<<< ours",
+			"host=b",
+			"||||||| base",
+			"host=a",
+			"=======",
+			"host=c",
+			">>>>>>> theirs",
+			"port=2",
+		}, merged)
+	})
+}
@@ -0,0 +1,347 @@
+//go:build linux || darwin || dragonfly || freebsd || openbsd
+
+package arrayfuncs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"reflect"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	mmapMagic      = "AFMM"
+	mmapVersion    = 1
+	mmapHeaderSize = 64
+	// mmapMinCapacity is the count of elements reserved on the first append
+	mmapMinCapacity = 1024
+)
+
+/*
+MmapArray is an Array of fixed size elements stored on a file that is mapped on the memory,
+so it can be bigger than the RAM. The elements can be numbers, booleans and arrays or structs of them.
+The file starts with a header of 64 bytes that has the length and a fingerprint of the element type
+
+	"AFMM" version(uint32) fingerprint(uint64) element size(uint64) length(uint64), little endian
+
+	points, err := OpenMmapArray[Point]("points.bin")
+	defer points.Close()
+
+	points.Append(Point{X: 1, Y: 2})
+	first := points.At(0)
+
+➡ The pointers returned by At and Find point to the mapped file, they can change the file and they are invalid after Append or Close.
+The MmapArray isn't safe for concurrent use. It is built only on Linux, macOS, DragonFly, FreeBSD and OpenBSD,
+the systems where the syscall package has both mmap and msync
+*/
+type MmapArray[T comparable] struct {
+	file     *os.File
+	mapping  []byte
+	values   Array[T]
+	length   int
+	capacity int
+	size     int
+}
+
+// elementSize return the size of T
+func elementSize[T comparable]() int {
+	var zero T
+
+	return int(unsafe.Sizeof(zero))
+}
+
+// typeLayout describe the memory layout of the type, returning an error if it has pointers
+func typeLayout(t reflect.Type, builder *strings.Builder) error {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		builder.WriteString(t.Kind().String())
+	case reflect.Int, reflect.Uint:
+		// The size of int depends on the machine, so it is part of the layout
+		fmt.Fprintf(builder, "%s%d", t.Kind(), t.Size()*8)
+	case reflect.Array:
+		fmt.Fprintf(builder, "[%d]", t.Len())
+		return typeLayout(t.Elem(), builder)
+	case reflect.Struct:
+		builder.WriteString("struct{")
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			fmt.Fprintf(builder, "%s@%d ", field.Name, field.Offset)
+
+			if err := typeLayout(field.Type, builder); err != nil {
+				return err
+			}
+
+			builder.WriteString(";")
+		}
+
+		builder.WriteString("}")
+	default:
+		return fmt.Errorf("%w: %s has pointers", ErrUnsupportedType, t)
+	}
+
+	return nil
+}
+
+// typeFingerprint return a hash of the layout of T
+func typeFingerprint[T comparable]() (uint64, error) {
+	var builder strings.Builder
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if err := typeLayout(t, &builder); err != nil {
+		return 0, err
+	}
+
+	if t.Size() == 0 {
+		return 0, fmt.Errorf("%w: %s has no size", ErrUnsupportedType, t)
+	}
+
+	fmt.Fprintf(&builder, "size%d", t.Size())
+
+	hash := fnv.New64a()
+	hash.Write([]byte(builder.String()))
+
+	return hash.Sum64(), nil
+}
+
+// OpenMmapArray open the file as an MmapArray, creating it when it doesn't exist
+func OpenMmapArray[T comparable](path string) (res *MmapArray[T], err error) {
+	fingerprint, err := typeFingerprint[T]()
+	if err != nil {
+		return
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("arrayfuncs: open mmap array: %w", err)
+	}
+
+	res = &MmapArray[T]{file: file, size: elementSize[T]()}
+
+	if err = res.open(fingerprint); err != nil {
+		res.unmap()
+		file.Close()
+
+		return nil, err
+	}
+
+	return
+}
+
+// open write the header of a new file or check the header of an existing one, then map the file
+func (m *MmapArray[T]) open(fingerprint uint64) error {
+	info, err := m.file.Stat()
+	if err != nil {
+		return fmt.Errorf("arrayfuncs: open mmap array: %w", err)
+	}
+
+	if info.Size() == 0 {
+		header := make([]byte, mmapHeaderSize)
+
+		copy(header, mmapMagic)
+		binary.LittleEndian.PutUint32(header[4:], mmapVersion)
+		binary.LittleEndian.PutUint64(header[8:], fingerprint)
+		binary.LittleEndian.PutUint64(header[16:], uint64(m.size))
+
+		if _, err = m.file.WriteAt(header, 0); err != nil {
+			return fmt.Errorf("arrayfuncs: write mmap header: %w", err)
+		}
+
+		return m.remap(mmapHeaderSize)
+	}
+
+	if info.Size() < mmapHeaderSize {
+		return fmt.Errorf("%w: file is smaller than the header", ErrInvalidFormat)
+	}
+
+	if err = m.remap(int(info.Size())); err != nil {
+		return err
+	}
+
+	header := m.mapping[:mmapHeaderSize]
+
+	if string(header[:4]) != mmapMagic {
+		return fmt.Errorf("%w: missing header", ErrInvalidFormat)
+	}
+
+	if version := binary.LittleEndian.Uint32(header[4:]); version != mmapVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	if binary.LittleEndian.Uint64(header[8:]) != fingerprint || binary.LittleEndian.Uint64(header[16:]) != uint64(m.size) {
+		return ErrTypeMismatch
+	}
+
+	length := binary.LittleEndian.Uint64(header[24:])
+	if length > uint64(m.capacity) {
+		return fmt.Errorf("%w: length %d is bigger than the file", ErrInvalidFormat, length)
+	}
+
+	m.length = int(length)
+
+	return nil
+}
+
+// remap map the first fileSize bytes of the file again, after it changed its size
+func (m *MmapArray[T]) remap(fileSize int) (err error) {
+	if err = m.unmap(); err != nil {
+		return
+	}
+
+	m.mapping, err = syscall.Mmap(int(m.file.Fd()), 0, fileSize, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return fmt.Errorf("arrayfuncs: mmap: %w", err)
+	}
+
+	m.capacity = (fileSize - mmapHeaderSize) / m.size
+	m.values = Array[T]{}
+
+	if m.capacity > 0 {
+		m.values = unsafe.Slice((*T)(unsafe.Pointer(&m.mapping[mmapHeaderSize])), m.capacity)
+	}
+
+	return
+}
+
+func (m *MmapArray[T]) unmap() (err error) {
+	if m.mapping == nil {
+		return
+	}
+
+	err = syscall.Munmap(m.mapping)
+	m.mapping, m.values = nil, nil
+
+	return
+}
+
+// Len return the count of elements
+func (m *MmapArray[T]) Len() int {
+	return m.length
+}
+
+// At return the pointer to the element on the file, or nil if it doesn't exist. Negative indexes count from the end, like Array.At
+func (m *MmapArray[T]) At(index int) (res *T) {
+	index, ok := normalizeIndex(index, m.length)
+	if ok {
+		res = &m.values[index]
+	}
+
+	return
+}
+
+// Slice return a copy of the elements from start to end without the end, on the memory. Negative indexes count from the end
+func (m *MmapArray[T]) Slice(start int, end ...int) (res Array[T]) {
+	stop := m.length
+	if len(end) > 0 {
+		stop = end[0]
+	}
+
+	start, stop = clampRange(start, stop, m.length)
+
+	res = make(Array[T], stop-start)
+	copy(res, m.values[start:stop])
+
+	return
+}
+
+// IndexOf return the first index of the value or -1 if not found
+func (m *MmapArray[T]) IndexOf(value T) int {
+	for i := 0; i < m.length; i++ {
+		if m.values[i] == value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Find return the pointer to the first element on the file that satisfy the callback condition, or nil if not found
+func (m *MmapArray[T]) Find(callback func(v *T, i int) bool) (res *T) {
+	for i := 0; i < m.length; i++ {
+		if callback(&m.values[i], i) {
+			return &m.values[i]
+		}
+	}
+
+	return
+}
+
+// Filter return a copy on the memory of the elements that satisfy the callback condition
+func (m *MmapArray[T]) Filter(callback func(v *T, i int) bool) (res Array[T]) {
+	res = Array[T]{}
+
+	for i := 0; i < m.length; i++ {
+		if callback(&m.values[i], i) {
+			res = append(res, m.values[i])
+		}
+	}
+
+	return
+}
+
+// Append add the values on the end, growing the file when needed
+func (m *MmapArray[T]) Append(values ...T) error {
+	if m.mapping == nil {
+		return ErrClosed
+	}
+
+	if needed := m.length + len(values); needed > m.capacity {
+		capacity := max(m.capacity*2, needed, mmapMinCapacity)
+		fileSize := mmapHeaderSize + capacity*m.size
+
+		if err := m.file.Truncate(int64(fileSize)); err != nil {
+			return fmt.Errorf("arrayfuncs: grow mmap array: %w", err)
+		}
+
+		if err := m.remap(fileSize); err != nil {
+			return err
+		}
+	}
+
+	copy(m.values[m.length:], values)
+	m.length += len(values)
+
+	binary.LittleEndian.PutUint64(m.mapping[24:], uint64(m.length))
+
+	return nil
+}
+
+// Sync write the changes of the mapped memory to the file
+func (m *MmapArray[T]) Sync() error {
+	if m.mapping == nil {
+		return ErrClosed
+	}
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_MSYNC,
+		uintptr(unsafe.Pointer(&m.mapping[0])),
+		uintptr(len(m.mapping)),
+		syscall.MS_SYNC,
+	)
+	if errno != 0 {
+		return fmt.Errorf("arrayfuncs: msync: %w", errno)
+	}
+
+	return nil
+}
+
+// Close sync and unmap the file, removing the space reserved for the next appends. After it the MmapArray is empty
+func (m *MmapArray[T]) Close() error {
+	if m.mapping == nil {
+		return ErrClosed
+	}
+
+	err := errors.Join(m.Sync(), m.unmap())
+	err = errors.Join(err, m.file.Truncate(int64(mmapHeaderSize+m.length*m.size)))
+
+	m.length, m.capacity = 0, 0
+
+	return errors.Join(err, m.file.Close())
+}
@@ -0,0 +1,136 @@
+//go:build linux || darwin || dragonfly || freebsd || openbsd
+
+package arrayfuncs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	X, Y  float64
+	Label [4]byte
+	Valid bool
+}
+
+func TestMmapArray(t *testing.T) {
+	open := func(t *testing.T, path string) *arrayFuncs.MmapArray[point] {
+		arr, err := arrayFuncs.OpenMmapArray[point](path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return arr
+	}
+
+	points := func(count int) (res arrayFuncs.Array[point]) {
+		for i := 0; i < count; i++ {
+			res = append(res, point{X: float64(i), Y: float64(i * 2), Label: [4]byte{'p'}, Valid: i%2 == 0})
+		}
+
+		return
+	}
+
+	t.Run("AppendAndReopen", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "points.bin")
+		values := points(3000)
+
+		arr := open(t, path)
+		assert.Equal(t, 0, arr.Len())
+
+		assert.NoError(t, arr.Append(values[:10]...))
+		assert.NoError(t, arr.Append(values[10:]...))
+		assert.Equal(t, 3000, arr.Len())
+		assert.NoError(t, arr.Sync())
+		assert.NoError(t, arr.Close())
+
+		info, err := os.Stat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(64+3000*24), info.Size())
+
+		arr = open(t, path)
+		defer arr.Close()
+
+		assert.Equal(t, 3000, arr.Len())
+		assert.Equal(t, values, arr.Slice(0))
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		arr := open(t, filepath.Join(t.TempDir(), "points.bin"))
+		defer arr.Close()
+
+		assert.NoError(t, arr.Append(points(10)...))
+
+		assert.Equal(t, 9.0, arr.At(-1).X)
+		assert.Nil(t, arr.At(10))
+		assert.Equal(t, points(10)[2:4], arr.Slice(2, 4))
+		assert.Equal(t, 7, arr.IndexOf(points(10)[7]))
+		assert.Equal(t, -1, arr.IndexOf(point{X: 100}))
+
+		found := arr.Find(func(v *point, _ int) bool { return v.Y > 10 })
+		assert.Equal(t, 6.0, found.X)
+
+		valid := arr.Filter(func(v *point, _ int) bool { return v.Valid })
+		assert.Len(t, valid, 5)
+
+		// The pointers change the mapped file
+		arr.At(0).X = 42
+		assert.Equal(t, 42.0, arr.Slice(0, 1)[0].X)
+	})
+
+	t.Run("TypeMismatch", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "points.bin")
+
+		arr := open(t, path)
+		assert.NoError(t, arr.Append(points(1)...))
+		assert.NoError(t, arr.Close())
+
+		_, err := arrayFuncs.OpenMmapArray[[5]int64](path)
+		assert.ErrorIs(t, err, arrayFuncs.ErrTypeMismatch)
+
+		type other struct {
+			A, B  float64
+			Label [4]byte
+			Valid bool
+		}
+
+		_, err = arrayFuncs.OpenMmapArray[other](path)
+		assert.ErrorIs(t, err, arrayFuncs.ErrTypeMismatch)
+	})
+
+	t.Run("InvalidFiles", func(t *testing.T) {
+		dir := t.TempDir()
+
+		_, err := arrayFuncs.OpenMmapArray[string](filepath.Join(dir, "strings.bin"))
+		assert.ErrorIs(t, err, arrayFuncs.ErrUnsupportedType)
+
+		_, err = arrayFuncs.OpenMmapArray[*int](filepath.Join(dir, "pointers.bin"))
+		assert.ErrorIs(t, err, arrayFuncs.ErrUnsupportedType)
+
+		invalid := filepath.Join(dir, "invalid.bin")
+		assert.NoError(t, os.WriteFile(invalid, make([]byte, 100), 0o644))
+
+		_, err = arrayFuncs.OpenMmapArray[int64](invalid)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidFormat)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		arr, err := arrayFuncs.OpenMmapArray[float64](filepath.Join(t.TempDir(), "numbers.bin"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, arr.Append(1.5, 2.5))
+		assert.NoError(t, arr.Close())
+
+		assert.ErrorIs(t, arr.Append(3), arrayFuncs.ErrClosed)
+		assert.ErrorIs(t, arr.Close(), arrayFuncs.ErrClosed)
+
+		assert.Equal(t, 0, arr.Len())
+		assert.Nil(t, arr.At(0))
+		assert.Empty(t, arr.Slice(0))
+		assert.Equal(t, -1, arr.IndexOf(1.5))
+	})
+}
@@ -0,0 +1,14 @@
+package arrayfuncs
+
+import "errors"
+
+// The errors of MmapArray have no build tag, so the code that checks them compiles on every platform
+
+var (
+	// ErrUnsupportedType is returned when the element type has pointers, so it can't be stored on a file
+	ErrUnsupportedType = errors.New("arrayfuncs: unsupported element type")
+	// ErrTypeMismatch is returned when the file was written with another element type
+	ErrTypeMismatch = errors.New("arrayfuncs: element type doesn't match the file")
+	// ErrClosed is returned when a file backed Array, like MmapArray, is used after Close
+	ErrClosed = errors.New("arrayfuncs: array is closed")
+)
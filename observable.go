@@ -0,0 +1,357 @@
+package arrayfuncs
+
+import "sort"
+
+// ChangeKind is the kind of modification made in an ObservableArray
+type ChangeKind int
+
+const (
+	// ChangeInsert means that Values were inserted starting at Index
+	ChangeInsert ChangeKind = iota
+	// ChangeRemove means that Values were removed starting at Index
+	ChangeRemove
+	// ChangeUpdate means that the elements on Indexes changed from OldValues to Values
+	ChangeUpdate
+	// ChangeSort means that the elements were reordered, Indexes[i] is the old position of the element now at i
+	ChangeSort
+	// ChangeReverse means that the elements order was reversed
+	ChangeReverse
+)
+
+// String return the name of the change kind
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeInsert:
+		return "insert"
+	case ChangeRemove:
+		return "remove"
+	case ChangeUpdate:
+		return "update"
+	case ChangeSort:
+		return "sort"
+	case ChangeReverse:
+		return "reverse"
+	}
+
+	return "unknown"
+}
+
+// ChangeEvent describe one modification made in an ObservableArray
+type ChangeEvent[T comparable] struct {
+	Kind      ChangeKind
+	Index     int
+	Indexes   []int
+	Values    Array[T]
+	OldValues Array[T]
+}
+
+// Observable is implemented by the arrays and views that notify their changes
+type Observable[T comparable] interface {
+	Subscribe(listener func(events []ChangeEvent[T])) (unsubscribe func())
+	Snapshot() Array[T]
+}
+
+type subscription[T comparable] struct {
+	id       int
+	listener func(events []ChangeEvent[T])
+}
+
+// observers keep the listeners of an Observable
+type observers[T comparable] struct {
+	subscriptions []subscription[T]
+	nextID        int
+}
+
+// Subscribe register a listener for the changes and return a function that cancel the subscription
+func (o *observers[T]) Subscribe(listener func(events []ChangeEvent[T])) (unsubscribe func()) {
+	id := o.nextID
+	o.nextID++
+
+	o.subscriptions = append(o.subscriptions, subscription[T]{id, listener})
+
+	return func() {
+		for i := range o.subscriptions {
+			if o.subscriptions[i].id == id {
+				o.subscriptions = append(o.subscriptions[:i:i], o.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (o *observers[T]) notify(events []ChangeEvent[T]) {
+	subscriptions := append([]subscription[T]{}, o.subscriptions...)
+
+	for i := range subscriptions {
+		subscriptions[i].listener(events)
+	}
+}
+
+/*
+ObservableArray is an Array that notify the subscribers every time it is changed
+
+	o := NewObservableArray(1, 2, 3)
+
+	unsubscribe := o.Subscribe(func(events []ChangeEvent[int]) {
+		// events[0].Kind is ChangeInsert, events[0].Index is 3 and events[0].Values is {4}
+	})
+
+	o.Push(4)
+	unsubscribe()
+
+➡ Outside a transaction every notification has only one event
+*/
+type ObservableArray[T comparable] struct {
+	observers[T]
+
+	items Array[T]
+
+	inTransaction bool
+	pending       []ChangeEvent[T]
+}
+
+// NewObservableArray create an ObservableArray with the initial values
+func NewObservableArray[T comparable](values ...T) *ObservableArray[T] {
+	return &ObservableArray[T]{items: AnyToArrayKind(values)}
+}
+
+/*
+Transaction execute the callback holding all the events, and notify them together at the end.
+Sequential inserts and removes that touch the same region are coalesced in only one event
+*/
+func (o *ObservableArray[T]) Transaction(callback func(o *ObservableArray[T])) {
+	if o.inTransaction {
+		callback(o)
+		return
+	}
+
+	o.inTransaction = true
+
+	defer func() {
+		o.inTransaction = false
+		pending := o.pending
+		o.pending = nil
+
+		if len(pending) > 0 {
+			o.notify(pending)
+		}
+	}()
+
+	callback(o)
+}
+
+// Len return the elements count
+func (o *ObservableArray[T]) Len() int {
+	return len(o.items)
+}
+
+// At return an element based on index, like Array.At
+func (o *ObservableArray[T]) At(index int) *T {
+	return o.items.At(index)
+}
+
+// Snapshot return a copy of the current elements
+func (o *ObservableArray[T]) Snapshot() Array[T] {
+	return AnyToArrayKind(o.items)
+}
+
+// Push add one or more elements to the end of the array
+func (o *ObservableArray[T]) Push(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+
+	index := len(o.items)
+	o.items.Push(values...)
+
+	o.emit(ChangeEvent[T]{Kind: ChangeInsert, Index: index, Values: AnyToArrayKind(values)})
+}
+
+// Pop remove the last element from this array, and return it.
+// If the array is empty return nil
+func (o *ObservableArray[T]) Pop() (res *T) {
+	res = o.items.Pop()
+
+	if res != nil {
+		o.emit(ChangeEvent[T]{Kind: ChangeRemove, Index: len(o.items), Values: Array[T]{*res}})
+	}
+
+	return
+}
+
+// Shift remove the first element from this array, and return it.
+// If the array is empty return nil
+func (o *ObservableArray[T]) Shift() (res *T) {
+	res = o.items.Shift()
+
+	if res != nil {
+		o.emit(ChangeEvent[T]{Kind: ChangeRemove, Index: 0, Values: Array[T]{*res}})
+	}
+
+	return
+}
+
+// Unshift add elements to the array init
+func (o *ObservableArray[T]) Unshift(values ...T) (newLength int) {
+	newLength = o.items.Unshift(values...)
+
+	if len(values) > 0 {
+		o.emit(ChangeEvent[T]{Kind: ChangeInsert, Index: 0, Values: AnyToArrayKind(values)})
+	}
+
+	return
+}
+
+// Splice remove 'deleteCount' elements starting at 'start' and insert the items in their place, like Array.Splice
+func (o *ObservableArray[T]) Splice(start, deleteCount int, items ...T) (removed Array[T]) {
+	index := start
+	if index < 0 {
+		index += len(o.items)
+
+		if index < 0 {
+			index = 0
+		}
+	} else if index > len(o.items) {
+		index = len(o.items)
+	}
+
+	removed = o.items.Splice(start, deleteCount, items...)
+
+	if len(removed) > 0 {
+		o.emit(ChangeEvent[T]{Kind: ChangeRemove, Index: index, Values: AnyToArrayKind(removed)})
+	}
+
+	if len(items) > 0 {
+		o.emit(ChangeEvent[T]{Kind: ChangeInsert, Index: index, Values: AnyToArrayKind(items)})
+	}
+
+	return
+}
+
+// Fill set the value from start to end, like Array.Fill
+func (o *ObservableArray[T]) Fill(value T, start int, end ...int) {
+	old := AnyToArrayKind(o.items)
+
+	o.items.Fill(value, start, end...)
+
+	o.emitUpdates(old)
+}
+
+// Map iterate all elements with a callback function that can change the original value
+func (o *ObservableArray[T]) Map(callback func(v *T, i int)) {
+	old := AnyToArrayKind(o.items)
+
+	o.items.Map(callback)
+
+	o.emitUpdates(old)
+}
+
+/*
+Sort sorts the array based on comparable function callback passing the index of the current element and the next element,
+like Array.Sort. Use At to read the elements inside the callback
+*/
+func (o *ObservableArray[T]) Sort(callback func(index1, index2 int) bool) {
+	s := &permutationSorter[T]{items: o.items, positions: o.items.Keys(), less: callback}
+
+	sort.Stable(s)
+
+	o.emit(ChangeEvent[T]{Kind: ChangeSort, Indexes: s.positions})
+}
+
+// Reverse reverses the order of the elements
+func (o *ObservableArray[T]) Reverse() {
+	o.items.Reverse()
+
+	positions := make([]int, len(o.items))
+	for i := range positions {
+		positions[i] = len(positions) - 1 - i
+	}
+
+	o.emit(ChangeEvent[T]{Kind: ChangeReverse, Indexes: positions})
+}
+
+func (o *ObservableArray[T]) emitUpdates(old Array[T]) {
+	event := ChangeEvent[T]{Kind: ChangeUpdate}
+
+	for i := range o.items {
+		if o.items[i] != old[i] {
+			event.Indexes = append(event.Indexes, i)
+			event.Values = append(event.Values, o.items[i])
+			event.OldValues = append(event.OldValues, old[i])
+		}
+	}
+
+	if len(event.Indexes) > 0 {
+		o.emit(event)
+	}
+}
+
+func (o *ObservableArray[T]) emit(event ChangeEvent[T]) {
+	if !o.inTransaction {
+		o.notify([]ChangeEvent[T]{event})
+		return
+	}
+
+	if len(o.pending) > 0 && coalesce(&o.pending[len(o.pending)-1], event) {
+		return
+	}
+
+	o.pending = append(o.pending, event)
+}
+
+// coalesce merge the next event inside the last one when both touch the same region
+func coalesce[T comparable](last *ChangeEvent[T], next ChangeEvent[T]) bool {
+	if last.Kind != next.Kind {
+		return false
+	}
+
+	switch next.Kind {
+	case ChangeInsert:
+		// Pushing one after another
+		if next.Index == last.Index+len(last.Values) {
+			last.Values = last.Values.Concat(&next.Values)
+			return true
+		}
+
+		// Unshifting one before another
+		if next.Index == last.Index {
+			last.Values = next.Values.Concat(&last.Values)
+			return true
+		}
+	case ChangeRemove:
+		// Shifting, the next element removed is on the same position
+		if next.Index == last.Index {
+			last.Values = last.Values.Concat(&next.Values)
+			return true
+		}
+
+		// Popping, the next element removed is before the last one
+		if next.Index+len(next.Values) == last.Index {
+			last.Index = next.Index
+			last.Values = next.Values.Concat(&last.Values)
+			return true
+		}
+	}
+
+	return false
+}
+
+// permutationSorter sort the items keeping track of the original position of each one
+type permutationSorter[T comparable] struct {
+	items     Array[T]
+	positions []int
+	less      func(index1, index2 int) bool
+}
+
+func (s *permutationSorter[T]) Len() int {
+	return len(s.items)
+}
+
+func (s *permutationSorter[T]) Less(i, j int) bool {
+	return s.less(i, j)
+}
+
+func (s *permutationSorter[T]) Swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	s.positions[i], s.positions[j] = s.positions[j], s.positions[i]
+}
@@ -0,0 +1,136 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableArray(t *testing.T) {
+
+	record := func(o *arrayFuncs.ObservableArray[int]) (*[][]arrayFuncs.ChangeEvent[int], func()) {
+		notifications := [][]arrayFuncs.ChangeEvent[int]{}
+
+		unsubscribe := o.Subscribe(func(events []arrayFuncs.ChangeEvent[int]) {
+			notifications = append(notifications, events)
+		})
+
+		return &notifications, unsubscribe
+	}
+
+	t.Run("InsertAndRemove", func(t *testing.T) {
+		o := arrayFuncs.NewObservableArray(1, 2, 3)
+		notifications, _ := record(o)
+
+		o.Push(4, 5)
+		o.Pop()
+		o.Shift()
+		o.Unshift(0)
+
+		assert.Equal(t, [][]arrayFuncs.ChangeEvent[int]{
+			{{Kind: arrayFuncs.ChangeInsert, Index: 3, Values: arrayFuncs.Array[int]{4, 5}}},
+			{{Kind: arrayFuncs.ChangeRemove, Index: 4, Values: arrayFuncs.Array[int]{5}}},
+			{{Kind: arrayFuncs.ChangeRemove, Index: 0, Values: arrayFuncs.Array[int]{1}}},
+			{{Kind: arrayFuncs.ChangeInsert, Index: 0, Values: arrayFuncs.Array[int]{0}}},
+		}, *notifications)
+
+		assert.Equal(t, arrayFuncs.Array[int]{0, 2, 3, 4}, o.Snapshot())
+	})
+
+	t.Run("Splice", func(t *testing.T) {
+		o := arrayFuncs.NewObservableArray(1, 2, 3, 4)
+		notifications, _ := record(o)
+
+		o.Splice(-3, 2, 9)
+
+		assert.Equal(t, [][]arrayFuncs.ChangeEvent[int]{
+			{{Kind: arrayFuncs.ChangeRemove, Index: 1, Values: arrayFuncs.Array[int]{2, 3}}},
+			{{Kind: arrayFuncs.ChangeInsert, Index: 1, Values: arrayFuncs.Array[int]{9}}},
+		}, *notifications)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		o := arrayFuncs.NewObservableArray(1, 2, 3, 4)
+		notifications, _ := record(o)
+
+		o.Fill(0, 2)
+		o.Map(func(v *int, i int) {
+			if i == 0 {
+				*v = 10
+			}
+		})
+
+		assert.Equal(t, [][]arrayFuncs.ChangeEvent[int]{
+			{{
+				Kind:      arrayFuncs.ChangeUpdate,
+				Indexes:   []int{2, 3},
+				Values:    arrayFuncs.Array[int]{0, 0},
+				OldValues: arrayFuncs.Array[int]{3, 4},
+			}},
+			{{
+				Kind:      arrayFuncs.ChangeUpdate,
+				Indexes:   []int{0},
+				Values:    arrayFuncs.Array[int]{10},
+				OldValues: arrayFuncs.Array[int]{1},
+			}},
+		}, *notifications)
+	})
+
+	t.Run("SortAndReverse", func(t *testing.T) {
+		o := arrayFuncs.NewObservableArray(30, 10, 20)
+		notifications, _ := record(o)
+
+		o.Sort(func(index1, index2 int) bool {
+			return *o.At(index1) < *o.At(index2)
+		})
+
+		assert.Equal(t, arrayFuncs.Array[int]{10, 20, 30}, o.Snapshot())
+
+		o.Reverse()
+
+		assert.Equal(t, arrayFuncs.Array[int]{30, 20, 10}, o.Snapshot())
+
+		assert.Equal(t, [][]arrayFuncs.ChangeEvent[int]{
+			{{Kind: arrayFuncs.ChangeSort, Indexes: []int{1, 2, 0}}},
+			{{Kind: arrayFuncs.ChangeReverse, Indexes: []int{2, 1, 0}}},
+		}, *notifications)
+	})
+
+	t.Run("Unsubscribe", func(t *testing.T) {
+		o := arrayFuncs.NewObservableArray[int]()
+		notifications, unsubscribe := record(o)
+
+		o.Push(1)
+		unsubscribe()
+		o.Push(2)
+
+		assert.Equal(t, 1, len(*notifications))
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		o := arrayFuncs.NewObservableArray(1, 2, 3, 4)
+		notifications, _ := record(o)
+
+		o.Transaction(func(o *arrayFuncs.ObservableArray[int]) {
+			o.Push(5)
+			o.Push(6, 7)
+			o.Pop()
+			o.Pop()
+			o.Fill(0, 0, 0)
+		})
+
+		assert.Equal(t, [][]arrayFuncs.ChangeEvent[int]{
+			{
+				{Kind: arrayFuncs.ChangeInsert, Index: 4, Values: arrayFuncs.Array[int]{5, 6, 7}},
+				{Kind: arrayFuncs.ChangeRemove, Index: 5, Values: arrayFuncs.Array[int]{6, 7}},
+				{
+					Kind:      arrayFuncs.ChangeUpdate,
+					Indexes:   []int{0},
+					Values:    arrayFuncs.Array[int]{0},
+					OldValues: arrayFuncs.Array[int]{1},
+				},
+			},
+		}, *notifications)
+	})
+}
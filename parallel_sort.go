@@ -0,0 +1,208 @@
+package arrayfuncs
+
+import (
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	// parallelSortThreshold is the size under which SortParallel uses only one goroutine
+	parallelSortThreshold = 1 << 13
+	// radixSortThreshold is the size under which the radix sorts use the pdqsort
+	radixSortThreshold = 1 << 8
+)
+
+/*
+SortParallel sorts the Array with a merge sort that uses all the cores.
+Each core sorts one part of the Array with pdqsort and then the parts are merged in parallel.
+
+➡ Small Arrays are sorted with only pdqsort. The order of equivalent elements isn't kept
+*/
+func (l *Array[T]) SortParallel(cmp Comparator[T]) {
+	items := *l
+
+	if len(items) < parallelSortThreshold {
+		sort.Slice(items, func(i, j int) bool { return cmp(items[i], items[j]) < 0 })
+		return
+	}
+
+	parts := runtime.GOMAXPROCS(0)
+	if parts < 2 {
+		parts = 2
+	}
+
+	size := (len(items) + parts - 1) / parts
+
+	var bounds []int
+	for start := 0; start < len(items); start += size {
+		bounds = append(bounds, start)
+	}
+
+	bounds = append(bounds, len(items))
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < len(bounds)-1; i++ {
+		wg.Add(1)
+
+		go func(part Array[T]) {
+			defer wg.Done()
+
+			sort.Slice(part, func(i, j int) bool { return cmp(part[i], part[j]) < 0 })
+		}(items[bounds[i]:bounds[i+1]])
+	}
+
+	wg.Wait()
+
+	var (
+		source = items
+		target = make(Array[T], len(items))
+	)
+
+	// Merge the neighbor parts until there is only one
+	for len(bounds) > 2 {
+		var next []int
+
+		for i := 0; i < len(bounds)-1; i += 2 {
+			next = append(next, bounds[i])
+
+			if i+2 >= len(bounds) {
+				// Odd part without a pair is only copied
+				copy(target[bounds[i]:bounds[i+1]], source[bounds[i]:bounds[i+1]])
+				continue
+			}
+
+			wg.Add(1)
+
+			go func(start, middle, end int) {
+				defer wg.Done()
+
+				mergeSorted(target[start:end], source[start:middle], source[middle:end], cmp)
+			}(bounds[i], bounds[i+1], bounds[i+2])
+		}
+
+		wg.Wait()
+
+		next = append(next, len(items))
+		bounds = next
+		source, target = target, source
+	}
+
+	if &source[0] != &items[0] {
+		copy(items, source)
+	}
+}
+
+// mergeSorted merge the sorted a and b into target, taking from a first on ties
+func mergeSorted[T comparable](target, a, b Array[T], cmp Comparator[T]) {
+	i, j, k := 0, 0, 0
+
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			target[k] = b[j]
+			j++
+		} else {
+			target[k] = a[i]
+			i++
+		}
+
+		k++
+	}
+
+	k += copy(target[k:], a[i:])
+	copy(target[k:], b[j:])
+}
+
+/*
+SortByUintKey sorts the Array by the key with a LSD radix sort, in O(n) time.
+The radix sort keeps the order of the elements with equal keys.
+
+➡ Small Arrays are sorted with pdqsort, that doesn't keep the order of equal keys
+*/
+func (l *Array[T]) SortByUintKey(key func(v T) uint64) {
+	items := *l
+
+	if len(items) < radixSortThreshold {
+		sort.Slice(items, func(i, j int) bool { return key(items[i]) < key(items[j]) })
+		return
+	}
+
+	keys := make([]uint64, len(items))
+	for i := range items {
+		keys[i] = key(items[i])
+	}
+
+	radixSort(items, keys)
+}
+
+// SortByIntKey sorts the Array by the signed key, like SortByUintKey
+func (l *Array[T]) SortByIntKey(key func(v T) int64) {
+	l.SortByUintKey(func(v T) uint64 {
+		return uint64(key(v)) ^ (1 << 63)
+	})
+}
+
+// SortByFloatKey sorts the Array by the float key, like SortByUintKey. The NaN values go to the end
+func (l *Array[T]) SortByFloatKey(key func(v T) float64) {
+	l.SortByUintKey(func(v T) uint64 {
+		value := key(v)
+
+		if math.IsNaN(value) {
+			return math.MaxUint64
+		}
+
+		bits := math.Float64bits(value)
+
+		// Negative numbers have all bits inverted so the greater magnitude goes first
+		if bits&(1<<63) != 0 {
+			return ^bits
+		}
+
+		return bits | (1 << 63)
+	})
+}
+
+// radixSort sorts the items by the keys, one byte at a time from the least significant
+func radixSort[T comparable](items Array[T], keys []uint64) {
+	var (
+		itemsBuffer = make(Array[T], len(items))
+		keysBuffer  = make([]uint64, len(keys))
+		sourceItems = items
+		sourceKeys  = keys
+	)
+
+	for shift := 0; shift < 64; shift += 8 {
+		var counts [256]int
+
+		for _, k := range sourceKeys {
+			counts[(k>>shift)&0xff]++
+		}
+
+		// All the keys have the same byte, nothing to do on this pass
+		if counts[(sourceKeys[0]>>shift)&0xff] == len(sourceKeys) {
+			continue
+		}
+
+		offset := 0
+		for i := range counts {
+			counts[i], offset = offset, offset+counts[i]
+		}
+
+		for i, k := range sourceKeys {
+			b := (k >> shift) & 0xff
+
+			itemsBuffer[counts[b]] = sourceItems[i]
+			keysBuffer[counts[b]] = k
+			counts[b]++
+		}
+
+		sourceItems, itemsBuffer = itemsBuffer, sourceItems
+		sourceKeys, keysBuffer = keysBuffer, sourceKeys
+	}
+
+	if &sourceItems[0] != &items[0] {
+		copy(items, sourceItems)
+	}
+}
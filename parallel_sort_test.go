@@ -0,0 +1,150 @@
+package arrayfuncs_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type keyed struct {
+	key   int64
+	order int
+}
+
+func randomInts(size int, seed int64) arrayFuncs.Array[int64] {
+	random := rand.New(rand.NewSource(seed))
+
+	res := make(arrayFuncs.Array[int64], size)
+	for i := range res {
+		res[i] = random.Int63() - math.MaxInt64/2
+	}
+
+	return res
+}
+
+func isSortedInts(arr arrayFuncs.Array[int64]) bool {
+	return sort.SliceIsSorted(arr, func(i, j int) bool { return arr[i] < arr[j] })
+}
+
+func TestParallelSort(t *testing.T) {
+	ascending := arrayFuncs.Ascending(func(v int64) int64 { return v })
+
+	t.Run("SortParallelSizes", func(t *testing.T) {
+		for _, size := range []int{0, 1, 10, 1000, 10000, 100003} {
+			arr := randomInts(size, int64(size))
+
+			arr.SortParallel(ascending)
+
+			assert.Len(t, arr, size)
+			assert.True(t, isSortedInts(arr), "size %d", size)
+		}
+	})
+
+	t.Run("SortParallelKeepsElements", func(t *testing.T) {
+		arr := randomInts(50000, 7)
+
+		expected := append(arrayFuncs.Array[int64]{}, arr...)
+		sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+
+		arr.SortParallel(ascending)
+
+		assert.Equal(t, expected, arr)
+	})
+
+	t.Run("SortParallelReversed", func(t *testing.T) {
+		arr := randomInts(20000, 3)
+
+		arr.SortParallel(ascending.Reverse())
+
+		assert.True(t, sort.SliceIsSorted(arr, func(i, j int) bool { return arr[i] > arr[j] }))
+	})
+
+	t.Run("SortByIntKeyNegatives", func(t *testing.T) {
+		for _, size := range []int{0, 5, 300, 50000} {
+			arr := randomInts(size, int64(size)+1)
+
+			arr.SortByIntKey(func(v int64) int64 { return v })
+
+			assert.True(t, isSortedInts(arr), "size %d", size)
+		}
+	})
+
+	t.Run("SortByUintKeyStable", func(t *testing.T) {
+		random := rand.New(rand.NewSource(11))
+
+		arr := make(arrayFuncs.Array[keyed], 5000)
+		for i := range arr {
+			arr[i] = keyed{key: random.Int63n(10), order: i}
+		}
+
+		arr.SortByUintKey(func(v keyed) uint64 { return uint64(v.key) })
+
+		for i := 1; i < len(arr); i++ {
+			assert.True(t, arr[i-1].key < arr[i].key || arr[i-1].key == arr[i].key && arr[i-1].order < arr[i].order)
+		}
+	})
+
+	t.Run("SortByFloatKeySpecialValues", func(t *testing.T) {
+		arr := arrayFuncs.Array[float64]{3.5, math.NaN(), -1, math.Inf(1), 0, -2.25, math.Inf(-1), 1e-9}
+
+		arr.SortByFloatKey(func(v float64) float64 { return v })
+
+		assert.Equal(t, []float64{math.Inf(-1), -2.25, -1, 0, 1e-9, 3.5, math.Inf(1)}, []float64(arr[:7]))
+		assert.True(t, math.IsNaN(arr[7]))
+	})
+
+	t.Run("SortByFloatKeyLarge", func(t *testing.T) {
+		random := rand.New(rand.NewSource(5))
+
+		arr := make(arrayFuncs.Array[float64], 10000)
+		for i := range arr {
+			arr[i] = random.NormFloat64() * 1000
+		}
+
+		arr.SortByFloatKey(func(v float64) float64 { return v })
+
+		assert.True(t, sort.Float64sAreSorted(arr))
+	})
+}
+
+var benchmarkSizes = []int{1000, 100000, 1000000} //nolint:gochecknoglobals
+
+func benchmarkSort(b *testing.B, sorter func(arr arrayFuncs.Array[int64])) {
+	for _, size := range benchmarkSizes {
+		source := randomInts(size, 1)
+
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			arr := make(arrayFuncs.Array[int64], size)
+
+			for i := 0; i < b.N; i++ {
+				copy(arr, source)
+				sorter(arr)
+			}
+		})
+	}
+}
+
+func BenchmarkSort(b *testing.B) {
+	benchmarkSort(b, func(arr arrayFuncs.Array[int64]) {
+		arr.Sort(func(i, j int) bool { return arr[i] < arr[j] })
+	})
+}
+
+func BenchmarkSortParallel(b *testing.B) {
+	ascending := arrayFuncs.Ascending(func(v int64) int64 { return v })
+
+	benchmarkSort(b, func(arr arrayFuncs.Array[int64]) {
+		arr.SortParallel(ascending)
+	})
+}
+
+func BenchmarkSortByIntKey(b *testing.B) {
+	benchmarkSort(b, func(arr arrayFuncs.Array[int64]) {
+		arr.SortByIntKey(func(v int64) int64 { return v })
+	})
+}
@@ -0,0 +1,223 @@
+package arrayfuncs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// ErrInvalidPath is returned when a field path doesn't exist on the element type
+var ErrInvalidPath = errors.New("arrayfuncs: invalid field path")
+
+type pathCacheKey struct {
+	t    reflect.Type
+	path string
+}
+
+// pathCache keep the resolved field paths by type and path
+var pathCache sync.Map //nolint:gochecknoglobals
+
+// fieldPathOf resolve the path for the element type T, using the cache when possible
+func fieldPathOf[T comparable](path string) (*fieldPath, error) {
+	key := pathCacheKey{reflect.TypeOf((*T)(nil)).Elem(), path}
+
+	if cached, ok := pathCache.Load(key); ok {
+		return cached.(*fieldPath), nil
+	}
+
+	resolved, err := resolveFieldPath(key.t, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPath, err)
+	}
+
+	pathCache.Store(key, resolved)
+
+	return resolved, nil
+}
+
+// valuesByPath return the value of the path for each element
+func valuesByPath[T comparable](arr Array[T], path string) (res []any, err error) {
+	resolved, err := fieldPathOf[T](path)
+	if err != nil {
+		return
+	}
+
+	res = make([]any, len(arr))
+
+	for i := range arr {
+		res[i] = resolved.get(reflect.ValueOf(&arr[i]).Elem())
+	}
+
+	return
+}
+
+// hashableValues return an error if any value can't be used as a map key
+func hashableValues(path string, values []any) error {
+	for _, v := range values {
+		if v != nil && !reflect.TypeOf(v).Comparable() {
+			return fmt.Errorf("%w: %q has values of type %T that can't be compared", ErrInvalidPath, path, v)
+		}
+	}
+
+	return nil
+}
+
+/*
+Pluck return the value of the field path of each element.
+The path is the field names or json tags separated by dots
+
+	cities, err := Pluck[User, string](users, "Address.City")
+*/
+func Pluck[T, V comparable](arr Array[T], path string) (res Array[V], err error) {
+	values, err := valuesByPath(arr, path)
+	if err != nil {
+		return
+	}
+
+	res = make(Array[V], len(values))
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+
+		value, ok := v.(V)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is %T, not %T", ErrInvalidPath, path, v, res[i])
+		}
+
+		res[i] = value
+	}
+
+	return
+}
+
+// SortByPath sorts the Array by the value of the field path, the nil values go first
+func (l *Array[T]) SortByPath(path string, descending ...bool) (err error) {
+	values, err := valuesByPath(*l, path)
+	if err != nil {
+		return
+	}
+
+	var (
+		desc    = len(descending) > 0 && descending[0]
+		indexes = l.Keys()
+	)
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := values[indexes[i]], values[indexes[j]]
+
+		if a == nil || b == nil {
+			return a == nil && b != nil
+		}
+
+		result, compareErr := compareValues(a, b)
+		if compareErr != nil {
+			if err == nil {
+				err = fmt.Errorf("%w: %s", ErrInvalidPath, compareErr)
+			}
+
+			return false
+		}
+
+		if desc {
+			return result > 0
+		}
+
+		return result < 0
+	})
+
+	if err != nil {
+		return
+	}
+
+	sorted := make(Array[T], len(*l))
+	for i, index := range indexes {
+		sorted[i] = (*l)[index]
+	}
+
+	*l = sorted
+
+	return
+}
+
+// GroupByPath return the elements grouped by the value of the field path, like Group
+func (l *Array[T]) GroupByPath(path string) (group map[any]Array[T], err error) {
+	values, err := valuesByPath(*l, path)
+	if err != nil {
+		return
+	}
+
+	if err = hashableValues(path, values); err != nil {
+		return
+	}
+
+	group = make(map[any]Array[T])
+
+	for i, v := range values {
+		group[v] = append(group[v], (*l)[i])
+	}
+
+	return
+}
+
+// KeyByPath return the elements indexed by the value of the field path, the last element wins when values repeat
+func (l *Array[T]) KeyByPath(path string) (res map[any]T, err error) {
+	values, err := valuesByPath(*l, path)
+	if err != nil {
+		return
+	}
+
+	if err = hashableValues(path, values); err != nil {
+		return
+	}
+
+	res = make(map[any]T, len(values))
+
+	for i, v := range values {
+		res[v] = (*l)[i]
+	}
+
+	return
+}
+
+// FindByPath return the first element where the value of the field path is equal to value, or nil if not found
+func (l *Array[T]) FindByPath(path string, value any) (res *T, err error) {
+	resolved, err := fieldPathOf[T](path)
+	if err != nil {
+		return
+	}
+
+	for i := range *l {
+		if equalValues(resolved.get(reflect.ValueOf(&(*l)[i]).Elem()), value) {
+			return &(*l)[i], nil
+		}
+	}
+
+	return
+}
+
+// UniqueByPath return the first element of each distinct value of the field path, keeping the order
+func (l *Array[T]) UniqueByPath(path string) (res Array[T], err error) {
+	values, err := valuesByPath(*l, path)
+	if err != nil {
+		return
+	}
+
+	if err = hashableValues(path, values); err != nil {
+		return
+	}
+
+	seen := make(map[any]bool, len(values))
+
+	for i, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			res = append(res, (*l)[i])
+		}
+	}
+
+	return
+}
@@ -0,0 +1,90 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaths(t *testing.T) {
+	people := func() arrayFuncs.Array[person] {
+		return arrayFuncs.Array[person]{
+			{"carl", 45, "red", &address{"Lisbon"}},
+			{"ann", 31, "blue", &address{"Porto"}},
+			{"bob", 25, "red", nil},
+			{"dan", 60, "blue", &address{"Lisbon"}},
+		}
+	}
+
+	namesOf := func(list arrayFuncs.Array[person]) (res []string) {
+		for _, p := range list {
+			res = append(res, p.Name)
+		}
+
+		return
+	}
+
+	t.Run("Pluck", func(t *testing.T) {
+		cities, err := arrayFuncs.Pluck[person, string](people(), "Address.City")
+
+		assert.Nil(t, err)
+		assert.Equal(t, arrayFuncs.Array[string]{"Lisbon", "Porto", "", "Lisbon"}, cities)
+
+		_, err = arrayFuncs.Pluck[person, int](people(), "name")
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidPath)
+	})
+
+	t.Run("SortByPath", func(t *testing.T) {
+		list := people()
+
+		assert.Nil(t, list.SortByPath("age"))
+		assert.Equal(t, []string{"bob", "ann", "carl", "dan"}, namesOf(list))
+
+		assert.Nil(t, list.SortByPath("address.city", true))
+		assert.Equal(t, []string{"bob", "ann", "carl", "dan"}, namesOf(list))
+
+		assert.ErrorIs(t, list.SortByPath("height"), arrayFuncs.ErrInvalidPath)
+	})
+
+	t.Run("GroupByPath", func(t *testing.T) {
+		list := people()
+		group, err := list.GroupByPath("Team")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"carl", "bob"}, namesOf(group["red"]))
+		assert.Equal(t, []string{"ann", "dan"}, namesOf(group["blue"]))
+	})
+
+	t.Run("KeyByPath", func(t *testing.T) {
+		list := people()
+		keyed, err := list.KeyByPath("name")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 31, keyed["ann"].Age)
+
+		_, err = list.KeyByPath("Address.Street")
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidPath)
+	})
+
+	t.Run("FindByPath", func(t *testing.T) {
+		list := people()
+		found, err := list.FindByPath("address.city", "Porto")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "ann", found.Name)
+
+		found, err = list.FindByPath("age", 99)
+
+		assert.Nil(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("UniqueByPath", func(t *testing.T) {
+		list := people()
+		unique, err := list.UniqueByPath("Address.City")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"carl", "ann", "bob"}, namesOf(unique))
+	})
+}
@@ -0,0 +1,578 @@
+package arrayfuncs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+)
+
+const (
+	persistentSnapshotFile = "snapshot"
+	persistentWALFile      = "wal"
+	persistentMagic        = "AFPS"
+	persistentVersion      = 1
+	// persistentRecordHeader is the size of the length and the checksum before each record
+	persistentRecordHeader = 8
+	// defaultCompactAfter is the count of records on the log before a compaction, when PersistentConfig.CompactAfter is 0
+	defaultCompactAfter = 1000
+)
+
+var (
+	// ErrCorruptSnapshot is returned when the snapshot file is damaged
+	ErrCorruptSnapshot = errors.New("arrayfuncs: corrupt snapshot")
+	// ErrCorruptLog is returned when a record of the log before the last one is damaged,
+	// or a complete record can't be decoded, like when the Codec is another one
+	ErrCorruptLog = errors.New("arrayfuncs: corrupt log")
+)
+
+// Codec convert values to bytes and back
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(v any) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	err := gob.NewEncoder(&buffer).Encode(v)
+
+	return buffer.Bytes(), err
+}
+
+func (gobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+var (
+	// GobCodec encode the values with encoding/gob. Each value is encoded alone, so the description of its type
+	// is written every time, around 150 bytes on each record of the log for a small struct
+	GobCodec Codec = gobCodec{} //nolint:gochecknoglobals
+	// JSONCodec encode the values with encoding/json
+	JSONCodec Codec = jsonCodec{} //nolint:gochecknoglobals
+)
+
+/*
+PersistentConfig configure a PersistentArray
+
+	Codec           how the elements are written, GobCodec by default.
+	                GobCodec writes the type description on every record, JSONCodec makes smaller records for small values
+	CompactAfter    the count of records on the log before it is compacted on a snapshot, 1000 by default.
+	                When the compaction fails the records stay on the log and it is tried again after CompactAfter more records
+	OnCompactError  called with the error of a failed automatic compaction, because the mutating call that
+	                started it succeeds anyway. A call to Compact returns its error instead
+	SyncWrites      call fsync after each record, so no change is lost when the machine crashes
+*/
+type PersistentConfig struct {
+	Codec          Codec
+	CompactAfter   int
+	OnCompactError func(err error)
+	SyncWrites     bool
+}
+
+// persistentOp is the mutating method of a log record
+type persistentOp string
+
+const (
+	opPush    persistentOp = "push"
+	opPop     persistentOp = "pop"
+	opShift   persistentOp = "shift"
+	opUnshift persistentOp = "unshift"
+	opFill    persistentOp = "fill"
+	opSplice  persistentOp = "splice"
+	opSort    persistentOp = "sort"
+)
+
+// persistentRecord is one mutating call on the log
+type persistentRecord[T comparable] struct {
+	Seq    uint64
+	Op     persistentOp
+	Values []T
+	Start  int
+	Count  int
+	End    []int
+	Order  []int
+}
+
+// persistentSnapshot is all the elements after the record Seq
+type persistentSnapshot[T comparable] struct {
+	Seq    uint64
+	Values []T
+}
+
+/*
+PersistentArray is an Array that is kept on a directory, so it survives the process restarts.
+Each mutating call is written on an append only log before it changes the elements,
+and the log is compacted on a snapshot from time to time.
+
+When it is opened the snapshot is loaded and the log is replayed. Only the last record can be partially written,
+because the process crashed while writing it, so a last record that is cut or has a wrong checksum is removed.
+A damaged record before the last one, or a record with the right checksum that can't be decoded,
+returns ErrCorruptLog and the log isn't changed
+
+	users, err := OpenPersistentArray[User]("data/users", PersistentConfig{Codec: JSONCodec})
+	defer users.Close()
+
+	err = users.Push(User{Name: "Ann"})
+
+➡ The PersistentArray isn't safe for concurrent use, and only one process can open the directory
+*/
+type PersistentArray[T comparable] struct {
+	dir    string
+	config PersistentConfig
+	items  Array[T]
+	wal    *os.File
+	// offset is where the last complete record of the log ends
+	offset    int64
+	seq       uint64
+	records   int
+	compactAt int
+	// failure is the error that left the log with a partial record that couldn't be removed
+	failure error
+}
+
+// OpenPersistentArray open the PersistentArray on the directory, creating it when it doesn't exist
+func OpenPersistentArray[T comparable](dir string, config ...PersistentConfig) (res *PersistentArray[T], err error) {
+	res = &PersistentArray[T]{dir: dir, items: Array[T]{}}
+
+	if len(config) > 0 {
+		res.config = config[0]
+	}
+
+	if res.config.Codec == nil {
+		res.config.Codec = GobCodec
+	}
+
+	if res.config.CompactAfter <= 0 {
+		res.config.CompactAfter = defaultCompactAfter
+	}
+
+	res.compactAt = res.config.CompactAfter
+
+	if err = os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("arrayfuncs: open persistent array: %w", err)
+	}
+
+	if err = res.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	if err = res.replay(); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// castagnoli is the CRC-32C table used by the checksums
+var castagnoli = crc32.MakeTable(crc32.Castagnoli) //nolint:gochecknoglobals
+
+// checksum is the CRC-32C of the data
+func checksum(data []byte) uint32 {
+	return crc32.Checksum(data, castagnoli)
+}
+
+// loadSnapshot read the snapshot file, if there is one
+func (p *PersistentArray[T]) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(p.dir, persistentSnapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("arrayfuncs: read snapshot: %w", err)
+	}
+
+	// "AFPS" version(1 byte) checksum(uint32) payload
+	if len(data) < 9 || string(data[:4]) != persistentMagic {
+		return fmt.Errorf("%w: missing header", ErrCorruptSnapshot)
+	}
+
+	if data[4] != persistentVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[4])
+	}
+
+	payload := data[9:]
+	if binary.LittleEndian.Uint32(data[5:]) != checksum(payload) {
+		return fmt.Errorf("%w: wrong checksum", ErrCorruptSnapshot)
+	}
+
+	var snapshot persistentSnapshot[T]
+
+	if err = p.config.Codec.Unmarshal(payload, &snapshot); err != nil {
+		return fmt.Errorf("%w: %s", ErrCorruptSnapshot, err)
+	}
+
+	p.seq = snapshot.Seq
+	p.items = append(Array[T]{}, snapshot.Values...)
+
+	return nil
+}
+
+// replay apply the records of the log that are after the snapshot, removing the last record when it was partially written
+func (p *PersistentArray[T]) replay() (err error) {
+	p.wal, err = os.OpenFile(filepath.Join(p.dir, persistentWALFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("arrayfuncs: open log: %w", err)
+	}
+
+	data, err := io.ReadAll(p.wal)
+	if err != nil {
+		p.wal.Close()
+		return fmt.Errorf("arrayfuncs: read log: %w", err)
+	}
+
+	valid := 0
+
+	for len(data)-valid >= persistentRecordHeader {
+		var (
+			length = int(binary.LittleEndian.Uint32(data[valid:]))
+			sum    = binary.LittleEndian.Uint32(data[valid+4:])
+			start  = valid + persistentRecordHeader
+		)
+
+		// The record goes past the end of the file, so it was cut while being written
+		if length > len(data)-start {
+			break
+		}
+
+		if checksum(data[start:start+length]) != sum {
+			// Only the last record can be partially written, a damaged record before it means the file was changed
+			if start+length == len(data) {
+				break
+			}
+
+			p.wal.Close()
+			return fmt.Errorf("%w: wrong checksum on the record at byte %d", ErrCorruptLog, valid)
+		}
+
+		var record persistentRecord[T]
+
+		// The record was written completely, so removing it would lose data
+		if err = p.config.Codec.Unmarshal(data[start:start+length], &record); err != nil {
+			p.wal.Close()
+			return fmt.Errorf("%w: record at byte %d: %s", ErrCorruptLog, valid, err)
+		}
+
+		// Records before the snapshot were already compacted but the log wasn't cleared
+		if record.Seq > p.seq {
+			p.apply(record)
+			p.seq = record.Seq
+		}
+
+		p.records++
+		valid = start + length
+	}
+
+	if valid < len(data) {
+		if err = p.wal.Truncate(int64(valid)); err != nil {
+			p.wal.Close()
+			return fmt.Errorf("arrayfuncs: repair log: %w", err)
+		}
+	}
+
+	p.offset = int64(valid)
+
+	return nil
+}
+
+// apply change the elements as the record says
+func (p *PersistentArray[T]) apply(record persistentRecord[T]) {
+	switch record.Op {
+	case opPush:
+		p.items.Push(record.Values...)
+	case opPop:
+		p.items.Pop()
+	case opShift:
+		p.items.Shift()
+	case opUnshift:
+		p.items.Unshift(record.Values...)
+	case opFill:
+		p.items.Fill(record.Values[0], record.Start, record.End...)
+	case opSplice:
+		p.items.Splice(record.Start, record.Count, record.Values...)
+	case opSort:
+		sorted := make(Array[T], len(p.items))
+		for i, position := range record.Order {
+			sorted[i] = p.items[position]
+		}
+
+		p.items = sorted
+	}
+}
+
+// write add the record on the log, then apply it. After the record is on the log the call succeeds, even if the compaction fails
+func (p *PersistentArray[T]) write(record persistentRecord[T]) error {
+	if p.wal == nil {
+		return ErrClosed
+	}
+
+	if p.failure != nil {
+		return fmt.Errorf("arrayfuncs: log has a partial record, call Compact to repair it: %w", p.failure)
+	}
+
+	record.Seq = p.seq + 1
+
+	payload, err := p.config.Codec.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("arrayfuncs: encode record: %w", err)
+	}
+
+	data := make([]byte, persistentRecordHeader, persistentRecordHeader+len(payload))
+	binary.LittleEndian.PutUint32(data, uint32(len(payload)))
+	binary.LittleEndian.PutUint32(data[4:], checksum(payload))
+	data = append(data, payload...)
+
+	if _, err = p.wal.WriteAt(data, p.offset); err != nil {
+		return p.rollback(fmt.Errorf("arrayfuncs: write log: %w", err))
+	}
+
+	if p.config.SyncWrites {
+		if err = p.wal.Sync(); err != nil {
+			return p.rollback(fmt.Errorf("arrayfuncs: sync log: %w", err))
+		}
+	}
+
+	p.offset += int64(len(data))
+	p.apply(record)
+	p.seq = record.Seq
+	p.records++
+
+	if p.records >= p.compactAt {
+		// The record is already safe on the log, so a failed compaction is only reported and tried again later
+		if err = p.Compact(); err != nil {
+			p.compactAt = p.records + p.config.CompactAfter
+
+			if p.config.OnCompactError != nil {
+				p.config.OnCompactError(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// rollback remove the record that wasn't written completely, or keep the error when the log can't be repaired
+func (p *PersistentArray[T]) rollback(cause error) error {
+	if err := p.wal.Truncate(p.offset); err != nil {
+		p.failure = errors.Join(cause, err)
+		return p.failure
+	}
+
+	return cause
+}
+
+// Compact write all the elements on a new snapshot and clear the log, also removing a partial record left by a failed write
+func (p *PersistentArray[T]) Compact() error {
+	if p.wal == nil {
+		return ErrClosed
+	}
+
+	payload, err := p.config.Codec.Marshal(persistentSnapshot[T]{Seq: p.seq, Values: p.items})
+	if err != nil {
+		return fmt.Errorf("arrayfuncs: encode snapshot: %w", err)
+	}
+
+	data := make([]byte, 9, 9+len(payload))
+	copy(data, persistentMagic)
+	data[4] = persistentVersion
+	binary.LittleEndian.PutUint32(data[5:], checksum(payload))
+	data = append(data, payload...)
+
+	// The snapshot is written on a temp file and renamed, so a crash never leaves half of it
+	temp := filepath.Join(p.dir, persistentSnapshotFile+".tmp")
+
+	if err = writeFileSync(temp, data); err != nil {
+		return fmt.Errorf("arrayfuncs: write snapshot: %w", err)
+	}
+
+	if err = os.Rename(temp, filepath.Join(p.dir, persistentSnapshotFile)); err != nil {
+		return fmt.Errorf("arrayfuncs: write snapshot: %w", err)
+	}
+
+	// The rename is only durable after the directory is synced, and the log can't be cleared before that
+	if err = syncDir(p.dir); err != nil {
+		return fmt.Errorf("arrayfuncs: sync snapshot directory: %w", err)
+	}
+
+	// If the process stops here the records are skipped on the next open, because of their Seq
+	if err = p.wal.Truncate(0); err != nil {
+		return fmt.Errorf("arrayfuncs: clear log: %w", err)
+	}
+
+	p.offset = 0
+	p.records = 0
+	p.compactAt = p.config.CompactAfter
+	p.failure = nil
+
+	return nil
+}
+
+func writeFileSync(path string, data []byte) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// syncDir call fsync on the directory, so the files created and renamed on it survive a crash.
+// Windows can't sync a directory, so it is skipped there
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// Close sync the log and close it
+func (p *PersistentArray[T]) Close() error {
+	if p.wal == nil {
+		return ErrClosed
+	}
+
+	err := errors.Join(p.wal.Sync(), p.wal.Close())
+	p.wal = nil
+
+	return err
+}
+
+// Len return the count of elements
+func (p *PersistentArray[T]) Len() int {
+	return len(p.items)
+}
+
+// At return a copy of the element, or nil if it doesn't exist. Negative indexes count from the end, like Array.At
+func (p *PersistentArray[T]) At(index int) (res *T) {
+	index, ok := normalizeIndex(index, len(p.items))
+	if !ok {
+		return
+	}
+
+	value := p.items[index]
+
+	return &value
+}
+
+// Snapshot return a copy of the elements
+func (p *PersistentArray[T]) Snapshot() (res Array[T]) {
+	res = make(Array[T], len(p.items))
+	copy(res, p.items)
+
+	return
+}
+
+// Push add the values on the end
+func (p *PersistentArray[T]) Push(values ...T) error {
+	return p.write(persistentRecord[T]{Op: opPush, Values: values})
+}
+
+// Pop remove the last element and return it, or nil if the Array is empty
+func (p *PersistentArray[T]) Pop() (res *T, err error) {
+	if len(p.items) == 0 {
+		return
+	}
+
+	last := p.items[len(p.items)-1]
+
+	if err = p.write(persistentRecord[T]{Op: opPop}); err != nil {
+		return
+	}
+
+	return &last, nil
+}
+
+// Shift remove the first element and return it, or nil if the Array is empty
+func (p *PersistentArray[T]) Shift() (res *T, err error) {
+	if len(p.items) == 0 {
+		return
+	}
+
+	first := p.items[0]
+
+	if err = p.write(persistentRecord[T]{Op: opShift}); err != nil {
+		return
+	}
+
+	return &first, nil
+}
+
+// Unshift add the values on the start
+func (p *PersistentArray[T]) Unshift(values ...T) error {
+	return p.write(persistentRecord[T]{Op: opUnshift, Values: values})
+}
+
+// Fill set the value from the start index until the end index, like Array.Fill. A negative start returns ErrOutOfRange
+func (p *PersistentArray[T]) Fill(value T, start int, end ...int) error {
+	if start < 0 {
+		return fmt.Errorf("%w: start %d", ErrOutOfRange, start)
+	}
+
+	return p.write(persistentRecord[T]{Op: opFill, Values: []T{value}, Start: start, End: end})
+}
+
+// Splice remove deleteCount elements from start and insert the items, like Array.Splice
+func (p *PersistentArray[T]) Splice(start, deleteCount int, items ...T) (removed Array[T], err error) {
+	// Splice a copy to know the removed elements before writing the record
+	current := p.Snapshot()
+	removed = current.Splice(start, deleteCount, items...)
+
+	if err = p.write(persistentRecord[T]{Op: opSplice, Start: start, Count: deleteCount, Values: items}); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// Sort sorts the elements with the Comparator, the sort is stable. The log keeps only the new order
+func (p *PersistentArray[T]) Sort(cmp Comparator[T]) error {
+	order := p.items.Keys()
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return cmp(p.items[order[i]], p.items[order[j]]) < 0
+	})
+
+	return p.write(persistentRecord[T]{Op: opSort, Order: order})
+}
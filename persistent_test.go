@@ -0,0 +1,337 @@
+package arrayfuncs_test
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	Name  string
+	Score int
+}
+
+func TestPersistentArray(t *testing.T) {
+	byScore := arrayFuncs.Ascending(func(e entry) int { return e.Score })
+
+	open := func(t *testing.T, dir string, config ...arrayFuncs.PersistentConfig) *arrayFuncs.PersistentArray[entry] {
+		arr, err := arrayFuncs.OpenPersistentArray[entry](dir, config...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return arr
+	}
+
+	// mutate call every mutating method, checking the results
+	mutate := func(t *testing.T, arr *arrayFuncs.PersistentArray[entry]) {
+		assert.NoError(t, arr.Push(entry{"a", 3}, entry{"b", 1}, entry{"c", 2}, entry{"d", 5}))
+		assert.NoError(t, arr.Unshift(entry{"z", 4}))
+
+		popped, err := arr.Pop()
+		assert.NoError(t, err)
+		assert.Equal(t, entry{"d", 5}, *popped)
+
+		shifted, err := arr.Shift()
+		assert.NoError(t, err)
+		assert.Equal(t, entry{"z", 4}, *shifted)
+
+		removed, err := arr.Splice(1, 1, entry{"x", 9}, entry{"y", 0})
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[entry]{{"b", 1}}, removed)
+
+		assert.NoError(t, arr.Sort(byScore))
+		assert.NoError(t, arr.Fill(entry{"f", 7}, 3))
+	}
+
+	expected := arrayFuncs.Array[entry]{{"y", 0}, {"c", 2}, {"a", 3}, {"f", 7}}
+
+	t.Run("Reopen", func(t *testing.T) {
+		for name, codec := range map[string]arrayFuncs.Codec{"gob": arrayFuncs.GobCodec, "json": arrayFuncs.JSONCodec} {
+			t.Run(name, func(t *testing.T) {
+				dir := t.TempDir()
+				config := arrayFuncs.PersistentConfig{Codec: codec, SyncWrites: true}
+
+				arr := open(t, dir, config)
+				mutate(t, arr)
+				assert.Equal(t, expected, arr.Snapshot())
+				assert.NoError(t, arr.Close())
+
+				arr = open(t, dir, config)
+				defer arr.Close()
+
+				assert.Equal(t, expected, arr.Snapshot())
+				assert.Equal(t, 4, arr.Len())
+				assert.Equal(t, entry{"f", 7}, *arr.At(-1))
+			})
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		arr := open(t, t.TempDir())
+		defer arr.Close()
+
+		popped, err := arr.Pop()
+		assert.NoError(t, err)
+		assert.Nil(t, popped)
+
+		shifted, err := arr.Shift()
+		assert.NoError(t, err)
+		assert.Nil(t, shifted)
+
+		assert.ErrorIs(t, arr.Fill(entry{}, -1), arrayFuncs.ErrOutOfRange)
+		assert.Nil(t, arr.At(0))
+	})
+
+	t.Run("Compaction", func(t *testing.T) {
+		dir := t.TempDir()
+		config := arrayFuncs.PersistentConfig{CompactAfter: 3}
+
+		arr := open(t, dir, config)
+		mutate(t, arr)
+		assert.NoError(t, arr.Close())
+
+		_, err := os.Stat(filepath.Join(dir, "snapshot"))
+		assert.NoError(t, err)
+
+		arr = open(t, dir, config)
+		assert.Equal(t, expected, arr.Snapshot())
+
+		assert.NoError(t, arr.Compact())
+
+		info, err := os.Stat(filepath.Join(dir, "wal"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), info.Size())
+		assert.NoError(t, arr.Close())
+
+		arr = open(t, dir, config)
+		defer arr.Close()
+
+		assert.Equal(t, expected, arr.Snapshot())
+	})
+
+	t.Run("SnapshotSequence", func(t *testing.T) {
+		dir := t.TempDir()
+
+		arr := open(t, dir)
+		mutate(t, arr)
+		assert.NoError(t, arr.Close())
+
+		log, err := os.ReadFile(filepath.Join(dir, "wal"))
+		assert.NoError(t, err)
+
+		arr = open(t, dir)
+		assert.NoError(t, arr.Compact())
+		assert.NoError(t, arr.Close())
+
+		// Simulate a crash after the snapshot was renamed but before the log was cleared
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "wal"), log, 0o644))
+
+		arr = open(t, dir)
+		defer arr.Close()
+
+		assert.Equal(t, expected, arr.Snapshot())
+	})
+
+	t.Run("TornWrites", func(t *testing.T) {
+		dir := t.TempDir()
+		walPath := filepath.Join(dir, "wal")
+
+		arr := open(t, dir)
+		assert.NoError(t, arr.Push(entry{"a", 1}, entry{"b", 2}))
+		assert.NoError(t, arr.Close())
+
+		info, err := os.Stat(walPath)
+		assert.NoError(t, err)
+		firstSize := info.Size()
+
+		arr = open(t, dir)
+		assert.NoError(t, arr.Push(entry{"c", 3}))
+		assert.NoError(t, arr.Close())
+
+		complete, err := os.ReadFile(walPath)
+		assert.NoError(t, err)
+
+		// Cut the last record on every possible byte
+		for size := firstSize; size < int64(len(complete)); size++ {
+			assert.NoError(t, os.WriteFile(walPath, complete[:size], 0o644))
+
+			arr = open(t, dir)
+			assert.Equal(t, arrayFuncs.Array[entry]{{"a", 1}, {"b", 2}}, arr.Snapshot(), "size %d", size)
+
+			// The log keeps working after the repair
+			assert.NoError(t, arr.Push(entry{"d", 4}))
+			assert.NoError(t, arr.Close())
+
+			arr = open(t, dir)
+			assert.Equal(t, arrayFuncs.Array[entry]{{"a", 1}, {"b", 2}, {"d", 4}}, arr.Snapshot(), "size %d", size)
+			assert.NoError(t, arr.Close())
+		}
+	})
+
+	t.Run("Checksum", func(t *testing.T) {
+		dir := t.TempDir()
+		walPath := filepath.Join(dir, "wal")
+
+		arr := open(t, dir, arrayFuncs.PersistentConfig{Codec: arrayFuncs.JSONCodec})
+		assert.NoError(t, arr.Push(entry{"a", 1}))
+		assert.NoError(t, arr.Push(entry{"b", 2}))
+		assert.NoError(t, arr.Close())
+
+		log, err := os.ReadFile(walPath)
+		assert.NoError(t, err)
+
+		log[len(log)-3] ^= 0xff
+		assert.NoError(t, os.WriteFile(walPath, log, 0o644))
+
+		arr = open(t, dir, arrayFuncs.PersistentConfig{Codec: arrayFuncs.JSONCodec})
+		defer arr.Close()
+
+		assert.Equal(t, arrayFuncs.Array[entry]{{"a", 1}}, arr.Snapshot())
+	})
+
+	t.Run("DamagedMiddleRecord", func(t *testing.T) {
+		dir := t.TempDir()
+		walPath := filepath.Join(dir, "wal")
+		config := arrayFuncs.PersistentConfig{Codec: arrayFuncs.JSONCodec}
+
+		arr := open(t, dir, config)
+		assert.NoError(t, arr.Push(entry{"a", 1}))
+		assert.NoError(t, arr.Push(entry{"b", 2}))
+		assert.NoError(t, arr.Push(entry{"c", 3}))
+		assert.NoError(t, arr.Close())
+
+		log, err := os.ReadFile(walPath)
+		assert.NoError(t, err)
+
+		// The second byte of the payload of the first record
+		damaged := append([]byte{}, log...)
+		damaged[9] ^= 0xff
+		assert.NoError(t, os.WriteFile(walPath, damaged, 0o644))
+
+		_, err = arrayFuncs.OpenPersistentArray[entry](dir, config)
+		assert.ErrorIs(t, err, arrayFuncs.ErrCorruptLog)
+
+		after, err := os.ReadFile(walPath)
+		assert.NoError(t, err)
+		assert.Equal(t, damaged, after)
+
+		// A length that goes past the end of the file is a cut record, even on the first one
+		binary.LittleEndian.PutUint32(log, uint32(len(log)))
+		assert.NoError(t, os.WriteFile(walPath, log, 0o644))
+
+		arr = open(t, dir, config)
+		defer arr.Close()
+
+		assert.Empty(t, arr.Snapshot())
+	})
+
+	t.Run("CompactionFailure", func(t *testing.T) {
+		var compactErrors []error
+
+		dir := t.TempDir()
+		config := arrayFuncs.PersistentConfig{
+			CompactAfter:   2,
+			OnCompactError: func(err error) { compactErrors = append(compactErrors, err) },
+		}
+		walPath := filepath.Join(dir, "wal")
+
+		// The snapshot can't be created while its temp file is a directory
+		blocker := filepath.Join(dir, "snapshot.tmp")
+		assert.NoError(t, os.Mkdir(blocker, 0o755))
+
+		arr := open(t, dir, config)
+		assert.NoError(t, arr.Push(entry{"a", 1}, entry{"b", 2}))
+		assert.NoError(t, arr.Push(entry{"c", 3}))
+
+		popped, err := arr.Pop()
+		assert.NoError(t, err)
+		assert.Equal(t, entry{"c", 3}, *popped)
+
+		removed, err := arr.Splice(0, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[entry]{{"a", 1}}, removed)
+
+		// The automatic compactions after the 2nd and 4th records failed
+		assert.Len(t, compactErrors, 2)
+		assert.Error(t, arr.Compact())
+		assert.Len(t, compactErrors, 2)
+
+		info, err := os.Stat(walPath)
+		assert.NoError(t, err)
+		assert.NotZero(t, info.Size())
+
+		// The compaction is tried again after CompactAfter more records
+		assert.NoError(t, os.Remove(blocker))
+		assert.NoError(t, arr.Push(entry{"d", 4}))
+		assert.NoError(t, arr.Push(entry{"e", 5}))
+
+		info, err = os.Stat(walPath)
+		assert.NoError(t, err)
+		assert.Zero(t, info.Size())
+		assert.NoError(t, arr.Close())
+
+		arr = open(t, dir, config)
+		defer arr.Close()
+
+		assert.Equal(t, arrayFuncs.Array[entry]{{"b", 2}, {"d", 4}, {"e", 5}}, arr.Snapshot())
+	})
+
+	t.Run("WrongCodec", func(t *testing.T) {
+		dir := t.TempDir()
+		walPath := filepath.Join(dir, "wal")
+
+		arr := open(t, dir)
+		assert.NoError(t, arr.Push(entry{"a", 1}))
+		assert.NoError(t, arr.Push(entry{"b", 2}))
+		assert.NoError(t, arr.Close())
+
+		log, err := os.ReadFile(walPath)
+		assert.NoError(t, err)
+
+		_, err = arrayFuncs.OpenPersistentArray[entry](dir, arrayFuncs.PersistentConfig{Codec: arrayFuncs.JSONCodec})
+		assert.ErrorIs(t, err, arrayFuncs.ErrCorruptLog)
+
+		after, err := os.ReadFile(walPath)
+		assert.NoError(t, err)
+		assert.Equal(t, log, after)
+
+		arr = open(t, dir)
+		defer arr.Close()
+
+		assert.Equal(t, arrayFuncs.Array[entry]{{"a", 1}, {"b", 2}}, arr.Snapshot())
+	})
+
+	t.Run("DamagedSnapshot", func(t *testing.T) {
+		dir := t.TempDir()
+
+		arr := open(t, dir)
+		assert.NoError(t, arr.Push(entry{"a", 1}))
+		assert.NoError(t, arr.Compact())
+		assert.NoError(t, arr.Close())
+
+		snapshotPath := filepath.Join(dir, "snapshot")
+
+		data, err := os.ReadFile(snapshotPath)
+		assert.NoError(t, err)
+
+		data[len(data)-1] ^= 0xff
+		assert.NoError(t, os.WriteFile(snapshotPath, data, 0o644))
+
+		_, err = arrayFuncs.OpenPersistentArray[entry](dir)
+		assert.ErrorIs(t, err, arrayFuncs.ErrCorruptSnapshot)
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		arr := open(t, t.TempDir())
+		assert.NoError(t, arr.Close())
+
+		assert.ErrorIs(t, arr.Push(entry{}), arrayFuncs.ErrClosed)
+		assert.ErrorIs(t, arr.Compact(), arrayFuncs.ErrClosed)
+		assert.ErrorIs(t, arr.Close(), arrayFuncs.ErrClosed)
+	})
+}
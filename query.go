@@ -0,0 +1,1330 @@
+package arrayfuncs
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// QueryError is returned when the query can't be parsed or references an unknown field
+type QueryError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("query: line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+/*
+QueryResult is the result of a Query, each row is a map of the column name to the value.
+The rows can't be an Array because maps aren't comparable
+*/
+type QueryResult struct {
+	Columns []string
+	Rows    []map[string]any
+}
+
+/*
+Query execute a SQL SELECT over the elements of the Array, that must be structs or pointers to structs.
+The fields are found by the struct field name or by the json tag, and nested fields are separated by dots.
+
+	result, err := Query(users, "SELECT name, age FROM . WHERE age > 30 ORDER BY name LIMIT 10")
+
+	result.Rows[0]["name"] // the name of the first user
+
+➡ Supported clauses: WHERE, GROUP BY, ORDER BY (ASC or DESC), LIMIT and OFFSET.
+The aggregates COUNT(*), COUNT, SUM, AVG, MIN and MAX can be used with or without GROUP BY, also on ORDER BY,
+and the columns can be renamed with AS. The strings use single or double quotes, a quote inside is written twice
+or escaped with a backslash, like on CompilePredicate
+
+	WHERE name = 'it''s' OR name = 'it\'s'
+*/
+func Query[T comparable](arr Array[T], query string) (res *QueryResult, err error) {
+	statement, err := parseQuery(query)
+	if err != nil {
+		return
+	}
+
+	elementType := reflect.TypeOf((*T)(nil)).Elem()
+
+	if err = statement.bind(elementType); err != nil {
+		return
+	}
+
+	values := make([]reflect.Value, len(arr))
+	for i := range arr {
+		values[i] = reflect.ValueOf(&arr[i]).Elem()
+	}
+
+	return statement.execute(values)
+}
+
+/*
+QueryInto execute the Query and scan each row into a R struct, matching the columns with the field name or json tag
+
+	type Summary struct {
+		Name string
+		Age  int `json:"age"`
+	}
+
+	summaries, err := QueryInto[User, Summary](users, "SELECT name, age FROM .")
+*/
+func QueryInto[T, R comparable](arr Array[T], query string) (res Array[R], err error) {
+	result, err := Query(arr, query)
+	if err != nil {
+		return
+	}
+
+	fields, err := fieldsOf(reflect.TypeOf((*R)(nil)).Elem())
+	if err != nil {
+		return
+	}
+
+	res = make(Array[R], len(result.Rows))
+
+	for i, row := range result.Rows {
+		target := reflect.ValueOf(&res[i]).Elem()
+
+		for column, value := range row {
+			index, ok := fields.find(column)
+			if !ok || value == nil {
+				continue
+			}
+
+			field := target.FieldByIndex(index)
+			source := reflect.ValueOf(value)
+
+			if !source.Type().ConvertibleTo(field.Type()) {
+				return nil, fmt.Errorf("query: column %q of type %s can't be scanned into %s", column, source.Type(), field.Type())
+			}
+
+			field.Set(source.Convert(field.Type()))
+		}
+	}
+
+	return
+}
+
+// ---- Field resolution ----
+
+// structFields keep the index of each field by name, json tag and lower case name
+type structFields struct {
+	byName map[string][]int
+	names  []string
+}
+
+// fieldsCache keep the structFields by reflect.Type
+var fieldsCache sync.Map //nolint:gochecknoglobals
+
+func fieldsOf(t reflect.Type) (*structFields, error) {
+	if cached, ok := fieldsCache.Load(t); ok {
+		return cached.(*structFields), nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("query: %s isn't a struct", t)
+	}
+
+	fields := &structFields{byName: make(map[string][]int)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+			fields.byName[tag] = field.Index
+			name = tag
+		}
+
+		fields.names = append(fields.names, name)
+		fields.byName[field.Name] = field.Index
+
+		if _, ok := fields.byName[strings.ToLower(field.Name)]; !ok {
+			fields.byName[strings.ToLower(field.Name)] = field.Index
+		}
+	}
+
+	fieldsCache.Store(t, fields)
+
+	return fields, nil
+}
+
+func (f *structFields) find(name string) (index []int, ok bool) {
+	if index, ok = f.byName[name]; !ok {
+		index, ok = f.byName[strings.ToLower(name)]
+	}
+
+	return
+}
+
+// fieldPath is a resolved field, it has one index per part of the path
+type fieldPath struct {
+	indexes [][]int
+}
+
+func resolveFieldPath(t reflect.Type, path string) (*fieldPath, error) {
+	res := &fieldPath{}
+
+	for _, part := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		fields, err := fieldsOf(t)
+		if err != nil {
+			return nil, err
+		}
+
+		index, ok := fields.find(part)
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q on %s", part, t)
+		}
+
+		res.indexes = append(res.indexes, index)
+		t = t.FieldByIndex(index).Type
+	}
+
+	return res, nil
+}
+
+// get return the field value, nil when a pointer on the path is nil
+func (p *fieldPath) get(v reflect.Value) any {
+	for _, index := range p.indexes {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.FieldByIndex(index)
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	return v.Interface()
+}
+
+// ---- Lexer ----
+
+// queryRules are the lexer rules of the queries, the names are split on the dots
+var queryRules = lexRules{ //nolint:gochecknoglobals
+	identRune: func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+	},
+	operatorStart: "=<>!",
+	operatorNext:  "=>",
+	punctuation: map[rune]tokenKind{
+		',': tokenComma,
+		'.': tokenDot,
+		'*': tokenStar,
+		'(': tokenLeftParen,
+		')': tokenRightParen,
+		'-': tokenOperator,
+	},
+}
+
+func tokenize(query string) (tokens []token, err error) {
+	tokens, lexErr := lex(query, queryRules)
+	if lexErr != nil {
+		return nil, &QueryError{lexErr.line, lexErr.column, lexErr.message}
+	}
+
+	for _, t := range tokens {
+		if t.kind == tokenOperator && t.text == "!" {
+			return nil, &QueryError{t.line, t.column, "unexpected '!'"}
+		}
+	}
+
+	return
+}
+
+// ---- Parser ----
+
+type selectItem struct {
+	name      string
+	field     *queryField
+	aggregate string
+	star      bool
+	// hidden is an aggregate used only by ORDER BY, it isn't on the result
+	hidden bool
+}
+
+type orderItem struct {
+	expression queryExpression
+	// column is the output column of an aggregate, used instead of the expression
+	column     string
+	descending bool
+}
+
+type statement struct {
+	items   []selectItem
+	where   queryExpression
+	groupBy []*queryField
+	orderBy []orderItem
+	limit   int
+	offset  int
+
+	elementType reflect.Type
+}
+
+type parser struct {
+	tokens   []token
+	position int
+}
+
+func parseQuery(query string) (*statement, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	return p.parseStatement()
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.position]
+
+	if t.kind != tokenEOF {
+		p.position++
+	}
+
+	return t
+}
+
+func (p *parser) fail(t token, format string, args ...any) error {
+	return &QueryError{t.line, t.column, fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if t := p.next(); !t.is(keyword) {
+		return p.fail(t, "expected %s, found %q", keyword, t.text)
+	}
+
+	return nil
+}
+
+func (p *parser) parseStatement() (s *statement, err error) {
+	s = &statement{limit: -1}
+
+	if err = p.expectKeyword("SELECT"); err != nil {
+		return
+	}
+
+	if s.items, err = p.parseSelectItems(); err != nil {
+		return
+	}
+
+	if err = p.expectKeyword("FROM"); err != nil {
+		return
+	}
+
+	if t := p.next(); t.kind != tokenDot {
+		return nil, p.fail(t, "expected '.' after FROM, found %q", t.text)
+	}
+
+	if p.peek().is("WHERE") {
+		p.next()
+
+		if s.where, err = p.parseOr(); err != nil {
+			return
+		}
+	}
+
+	if p.peek().is("GROUP") {
+		p.next()
+
+		if err = p.expectKeyword("BY"); err != nil {
+			return
+		}
+
+		for {
+			field, err := p.parseField()
+			if err != nil {
+				return nil, err
+			}
+
+			s.groupBy = append(s.groupBy, field)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+
+			p.next()
+		}
+	}
+
+	if p.peek().is("ORDER") {
+		p.next()
+
+		if err = p.expectKeyword("BY"); err != nil {
+			return
+		}
+
+		for {
+			var item orderItem
+
+			if p.atAggregate() {
+				aggregate, err := p.parseAggregate()
+				if err != nil {
+					return nil, err
+				}
+
+				item.column = s.aggregateColumn(aggregate)
+			} else if item.expression, err = p.parseOperand(); err != nil {
+				return nil, err
+			}
+
+			if p.peek().is("DESC") {
+				p.next()
+				item.descending = true
+			} else if p.peek().is("ASC") {
+				p.next()
+			}
+
+			s.orderBy = append(s.orderBy, item)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+
+			p.next()
+		}
+	}
+
+	if p.peek().is("LIMIT") {
+		p.next()
+
+		if s.limit, err = p.parseInt(); err != nil {
+			return
+		}
+	}
+
+	if p.peek().is("OFFSET") {
+		p.next()
+
+		if s.offset, err = p.parseInt(); err != nil {
+			return
+		}
+	}
+
+	if t := p.next(); t.kind != tokenEOF {
+		return nil, p.fail(t, "unexpected %q", t.text)
+	}
+
+	return
+}
+
+func (p *parser) parseInt() (int, error) {
+	t := p.next()
+
+	value, err := strconv.Atoi(t.text)
+	if t.kind != tokenNumber || err != nil || value < 0 {
+		return 0, p.fail(t, "expected a positive integer, found %q", t.text)
+	}
+
+	return value, nil
+}
+
+func (p *parser) parseSelectItems() (items []selectItem, err error) {
+	for {
+		var item selectItem
+
+		t := p.peek()
+
+		switch {
+		case t.kind == tokenStar:
+			p.next()
+			item.star = true
+		case p.atAggregate():
+			if item, err = p.parseAggregate(); err != nil {
+				return
+			}
+		default:
+			if item.field, err = p.parseField(); err != nil {
+				return
+			}
+
+			item.name = item.field.path
+		}
+
+		if p.peek().is("AS") {
+			p.next()
+
+			alias := p.next()
+			if alias.kind != tokenIdent {
+				return nil, p.fail(alias, "expected an alias, found %q", alias.text)
+			}
+
+			item.name = alias.text
+		}
+
+		items = append(items, item)
+
+		if p.peek().kind != tokenComma {
+			return
+		}
+
+		p.next()
+	}
+}
+
+// atAggregate test if the next tokens are an aggregate call
+func (p *parser) atAggregate() bool {
+	return isAggregate(p.peek()) && p.tokens[p.position+1].kind == tokenLeftParen
+}
+
+// parseAggregate parse a call like COUNT(*) or SUM(age), the name is the call in lower case
+func (p *parser) parseAggregate() (item selectItem, err error) {
+	item.aggregate = strings.ToLower(p.next().text)
+	p.next()
+
+	if p.peek().kind == tokenStar && item.aggregate == "count" {
+		p.next()
+		item.name = "count(*)"
+	} else {
+		if item.field, err = p.parseField(); err != nil {
+			return
+		}
+
+		item.name = item.aggregate + "(" + item.field.path + ")"
+	}
+
+	if closing := p.next(); closing.kind != tokenRightParen {
+		return item, p.fail(closing, "expected ')', found %q", closing.text)
+	}
+
+	return
+}
+
+// aggregateColumn return the output column of the aggregate, adding it as a hidden item when it isn't selected
+func (s *statement) aggregateColumn(aggregate selectItem) string {
+	for _, item := range s.items {
+		if item.aggregate == aggregate.aggregate && (item.field == nil) == (aggregate.field == nil) &&
+			(item.field == nil || strings.EqualFold(item.field.path, aggregate.field.path)) {
+			return item.name
+		}
+	}
+
+	aggregate.hidden = true
+	s.items = append(s.items, aggregate)
+
+	return aggregate.name
+}
+
+func isReserved(t token) bool {
+	for _, keyword := range []string{"SELECT", "FROM", "WHERE", "GROUP", "ORDER", "BY", "LIMIT", "OFFSET", "AND", "OR", "NOT", "AS", "IS", "ASC", "DESC"} {
+		if t.is(keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAggregate(t token) bool {
+	for _, name := range []string{"COUNT", "SUM", "AVG", "MIN", "MAX"} {
+		if t.is(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) parseField() (*queryField, error) {
+	t := p.next()
+	if t.kind != tokenIdent || isReserved(t) {
+		return nil, p.fail(t, "expected a field, found %q", t.text)
+	}
+
+	field := &queryField{path: t.text, at: t}
+
+	for p.peek().kind == tokenDot {
+		p.next()
+
+		part := p.next()
+		if part.kind != tokenIdent {
+			return nil, p.fail(part, "expected a field, found %q", part.text)
+		}
+
+		field.path += "." + part.text
+	}
+
+	return field, nil
+}
+
+func (p *parser) parseOr() (queryExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("OR") {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicExpression{or: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (queryExpression, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("AND") {
+		p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &logicExpression{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (queryExpression, error) {
+	if p.peek().is("NOT") {
+		p.next()
+
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notExpression{operand}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (queryExpression, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().is("IS") {
+		p.next()
+
+		negate := false
+		if p.peek().is("NOT") {
+			p.next()
+			negate = true
+		}
+
+		if err := p.expectKeyword("NULL"); err != nil {
+			return nil, err
+		}
+
+		var res queryExpression = &comparison{operator: "=", left: left, right: &literal{nil}}
+		if negate {
+			res = &notExpression{res}
+		}
+
+		return res, nil
+	}
+
+	if t := p.peek(); t.kind == tokenOperator && t.text != "-" {
+		p.next()
+
+		switch t.text {
+		case "=", "!=", "<>", "<", "<=", ">", ">=":
+		default:
+			return nil, p.fail(t, "unknown operator %q", t.text)
+		}
+
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return &comparison{operator: t.text, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseOperand() (queryExpression, error) {
+	t := p.peek()
+
+	switch {
+	case t.kind == tokenLeftParen:
+		p.next()
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, p.fail(closing, "expected ')', found %q", closing.text)
+		}
+
+		return inner, nil
+	case t.kind == tokenNumber || (t.kind == tokenOperator && t.text == "-"):
+		p.next()
+
+		text := t.text
+		if t.text == "-" {
+			number := p.next()
+			if number.kind != tokenNumber {
+				return nil, p.fail(number, "expected a number, found %q", number.text)
+			}
+
+			text += number.text
+		}
+
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, p.fail(t, "invalid number %q", text)
+		}
+
+		return &literal{value}, nil
+	case t.kind == tokenString:
+		p.next()
+		return &literal{t.text}, nil
+	case t.is("TRUE"), t.is("FALSE"):
+		p.next()
+		return &literal{t.is("TRUE")}, nil
+	case t.is("NULL"):
+		p.next()
+		return &literal{nil}, nil
+	case t.kind == tokenIdent:
+		return p.parseField()
+	}
+
+	return nil, p.fail(t, "unexpected %q", t.text)
+}
+
+// ---- Expressions ----
+
+type queryExpression interface {
+	eval(row reflect.Value) (any, error)
+}
+
+type literal struct {
+	value any
+}
+
+func (l *literal) eval(reflect.Value) (any, error) {
+	return l.value, nil
+}
+
+type queryField struct {
+	path     string
+	at       token
+	resolved *fieldPath
+}
+
+func (f *queryField) eval(row reflect.Value) (any, error) {
+	return normalizeValue(f.resolved.get(row)), nil
+}
+
+type logicExpression struct {
+	or          bool
+	left, right queryExpression
+}
+
+func (l *logicExpression) eval(row reflect.Value) (any, error) {
+	left, err := evalBool(l.left, row)
+	if err != nil || left == l.or {
+		return left, err
+	}
+
+	return evalBool(l.right, row)
+}
+
+type notExpression struct {
+	operand queryExpression
+}
+
+func (n *notExpression) eval(row reflect.Value) (any, error) {
+	value, err := evalBool(n.operand, row)
+
+	return !value, err
+}
+
+type comparison struct {
+	operator    string
+	left, right queryExpression
+}
+
+func (c *comparison) eval(row reflect.Value) (any, error) {
+	left, err := c.left.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := c.right.eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if left == nil || right == nil {
+		switch c.operator {
+		case "=":
+			return left == right, nil
+		case "!=", "<>":
+			return left != right, nil
+		}
+
+		return false, nil
+	}
+
+	result, err := compareValues(left, right)
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.operator {
+	case "=":
+		return result == 0, nil
+	case "!=", "<>":
+		return result != 0, nil
+	case "<":
+		return result < 0, nil
+	case "<=":
+		return result <= 0, nil
+	case ">":
+		return result > 0, nil
+	case ">=":
+		return result >= 0, nil
+	}
+
+	return nil, fmt.Errorf("query: unknown operator %q", c.operator)
+}
+
+func evalBool(e queryExpression, row reflect.Value) (bool, error) {
+	value, err := e.eval(row)
+	if err != nil {
+		return false, err
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("query: expected a boolean, found %v", value)
+	}
+
+	return b, nil
+}
+
+// normalizeValue convert all the numbers to float64, so they can be compared
+func normalizeValue(value any) any {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	}
+
+	return value
+}
+
+func compareValues(a, b any) (int, error) {
+	a, b = normalizeValue(a), normalizeValue(b)
+
+	switch x := a.(type) {
+	case float64:
+		if y, ok := b.(float64); ok {
+			return compareOrderedValues(x, y), nil
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			return compareOrderedValues(x, y), nil
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			if x == y {
+				return 0, nil
+			} else if !x {
+				return -1, nil
+			}
+
+			return 1, nil
+		}
+	}
+
+	return 0, fmt.Errorf("query: can't compare %v with %v", a, b)
+}
+
+// ---- Execution ----
+
+// bind resolve all the fields of the statement for the element type
+func (s *statement) bind(t reflect.Type) error {
+	var fields []*queryField
+
+	var collect func(e queryExpression)
+
+	collect = func(e queryExpression) {
+		switch v := e.(type) {
+		case *queryField:
+			fields = append(fields, v)
+		case *logicExpression:
+			collect(v.left)
+			collect(v.right)
+		case *notExpression:
+			collect(v.operand)
+		case *comparison:
+			collect(v.left)
+			collect(v.right)
+		}
+	}
+
+	for _, item := range s.items {
+		if item.field != nil {
+			fields = append(fields, item.field)
+		}
+	}
+
+	if s.where != nil {
+		collect(s.where)
+	}
+
+	fields = append(fields, s.groupBy...)
+
+	for _, item := range s.orderBy {
+		// Order by an alias or aggregate is resolved on the output row
+		if field, ok := item.expression.(*queryField); ok && s.outputColumn(field.path) >= 0 {
+			continue
+		}
+
+		collect(item.expression)
+	}
+
+	for _, field := range fields {
+		resolved, err := resolveFieldPath(t, field.path)
+		if err != nil {
+			return &QueryError{field.at.line, field.at.column, err.Error()}
+		}
+
+		field.resolved = resolved
+	}
+
+	if err := s.checkGrouping(); err != nil {
+		return err
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	s.elementType = t
+
+	for i := range s.items {
+		if s.items[i].star {
+			if _, err := fieldsOf(t); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkGrouping reject the columns that aren't aggregated or on GROUP BY, because each group has many values for them
+func (s *statement) checkGrouping() error {
+	if !s.hasAggregates() {
+		return nil
+	}
+
+	for _, item := range s.items {
+		if item.aggregate != "" || item.star {
+			continue
+		}
+
+		grouped := slices.ContainsFunc(s.groupBy, func(field *queryField) bool {
+			return slices.EqualFunc(field.resolved.indexes, item.field.resolved.indexes, slices.Equal[[]int])
+		})
+
+		if !grouped {
+			at := item.field.at
+			return &QueryError{at.line, at.column, fmt.Sprintf("%q must be on GROUP BY or used in an aggregate", item.field.path)}
+		}
+	}
+
+	return nil
+}
+
+func (s *statement) outputColumn(name string) int {
+	for i := range s.items {
+		if s.items[i].name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *statement) hasAggregates() bool {
+	for _, item := range s.items {
+		if item.aggregate != "" {
+			return true
+		}
+	}
+
+	return len(s.groupBy) > 0
+}
+
+// outputRow is a row of the result and the element (or the first element of the group) that produced it
+type outputRow struct {
+	values map[string]any
+	source reflect.Value
+}
+
+func (s *statement) execute(elements []reflect.Value) (res *QueryResult, err error) {
+	res = &QueryResult{}
+
+	var filtered []reflect.Value
+
+	for _, element := range elements {
+		if s.where != nil {
+			pass, err := evalBool(s.where, element)
+			if err != nil {
+				return nil, err
+			}
+
+			if !pass {
+				continue
+			}
+		}
+
+		filtered = append(filtered, element)
+	}
+
+	var rows []outputRow
+
+	if s.hasAggregates() {
+		rows, err = s.aggregateRows(filtered)
+	} else {
+		for _, element := range filtered {
+			rows = append(rows, outputRow{s.project(element), element})
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.sortRows(rows); err != nil {
+		return nil, err
+	}
+
+	start := s.offset
+	if start > len(rows) {
+		start = len(rows)
+	}
+
+	end := len(rows)
+	if s.limit >= 0 && s.limit < end-start {
+		end = start + s.limit
+	}
+
+	for _, row := range rows[start:end] {
+		for _, item := range s.items {
+			if item.hidden {
+				delete(row.values, item.name)
+			}
+		}
+
+		res.Rows = append(res.Rows, row.values)
+	}
+
+	res.Columns = s.columns()
+
+	return res, nil
+}
+
+func (s *statement) columns() (res []string) {
+	for _, item := range s.items {
+		if item.hidden {
+			continue
+		}
+
+		if !item.star {
+			res = append(res, item.name)
+			continue
+		}
+
+		if fields, err := fieldsOf(s.elementType); err == nil {
+			res = append(res, fields.names...)
+		}
+	}
+
+	return
+}
+
+func (s *statement) project(element reflect.Value) map[string]any {
+	row := make(map[string]any, len(s.items))
+
+	for _, item := range s.items {
+		if item.star {
+			v := element
+			for v.Kind() == reflect.Pointer && !v.IsNil() {
+				v = v.Elem()
+			}
+
+			if v.Kind() != reflect.Struct {
+				continue
+			}
+
+			fields, _ := fieldsOf(v.Type())
+			for _, name := range fields.names {
+				index, _ := fields.find(name)
+				row[name] = v.FieldByIndex(index).Interface()
+			}
+
+			continue
+		}
+
+		row[item.name] = item.field.resolved.get(element)
+	}
+
+	return row
+}
+
+func (s *statement) aggregateRows(elements []reflect.Value) (rows []outputRow, err error) {
+	type group struct {
+		elements []reflect.Value
+	}
+
+	var (
+		groups  []*group
+		indexes = make(map[string]int)
+	)
+
+	for _, element := range elements {
+		keyParts := make([]string, len(s.groupBy))
+
+		for i, field := range s.groupBy {
+			keyParts[i] = fmt.Sprintf("%#v", field.resolved.get(element))
+		}
+
+		key := strings.Join(keyParts, "\x00")
+
+		index, ok := indexes[key]
+		if !ok {
+			index = len(groups)
+			indexes[key] = index
+			groups = append(groups, &group{})
+		}
+
+		groups[index].elements = append(groups[index].elements, element)
+	}
+
+	// Aggregates without GROUP BY always return one row
+	if len(s.groupBy) == 0 && len(groups) == 0 {
+		groups = append(groups, &group{})
+	}
+
+	for _, g := range groups {
+		row := outputRow{values: make(map[string]any, len(s.items))}
+
+		if len(g.elements) > 0 {
+			row.source = g.elements[0]
+		}
+
+		for _, item := range s.items {
+			if item.star {
+				return nil, fmt.Errorf("query: * can't be used with aggregates")
+			}
+
+			if item.aggregate == "" {
+				if len(g.elements) > 0 {
+					row.values[item.name] = item.field.resolved.get(g.elements[0])
+				}
+
+				continue
+			}
+
+			if row.values[item.name], err = aggregateValues(item, g.elements); err != nil {
+				return
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return
+}
+
+func aggregateValues(item selectItem, elements []reflect.Value) (any, error) {
+	if item.field == nil {
+		return len(elements), nil
+	}
+
+	var (
+		count    int
+		sum      float64
+		extreme  any
+		operator = map[string]int{"min": -1, "max": 1}[item.aggregate]
+	)
+
+	for _, element := range elements {
+		value := item.field.resolved.get(element)
+		if value == nil {
+			continue
+		}
+
+		count++
+
+		if operator != 0 {
+			if extreme == nil {
+				extreme = value
+			} else if result, err := compareValues(value, extreme); err != nil {
+				return nil, err
+			} else if result == operator {
+				extreme = value
+			}
+
+			continue
+		}
+
+		if item.aggregate == "count" {
+			continue
+		}
+
+		number, ok := normalizeValue(value).(float64)
+		if !ok {
+			return nil, fmt.Errorf("query: %s needs numbers, %q has %v", item.aggregate, item.field.path, value)
+		}
+
+		sum += number
+	}
+
+	switch item.aggregate {
+	case "count":
+		return count, nil
+	case "sum":
+		return sum, nil
+	case "avg":
+		if count == 0 {
+			return nil, nil
+		}
+
+		return sum / float64(count), nil
+	}
+
+	return extreme, nil
+}
+
+func (s *statement) sortRows(rows []outputRow) (err error) {
+	if len(s.orderBy) == 0 {
+		return
+	}
+
+	value := func(row outputRow, item orderItem) (any, error) {
+		if item.column != "" {
+			return normalizeValue(row.values[item.column]), nil
+		}
+
+		if field, ok := item.expression.(*queryField); ok && s.outputColumn(field.path) >= 0 {
+			return normalizeValue(row.values[s.items[s.outputColumn(field.path)].name]), nil
+		}
+
+		if !row.source.IsValid() {
+			return nil, nil
+		}
+
+		return item.expression.eval(row.source)
+	}
+
+	// fail keep only the first error, the next comparisons can't clear it
+	fail := func(e error) bool {
+		if err == nil {
+			err = e
+		}
+
+		return false
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, item := range s.orderBy {
+			a, errA := value(rows[i], item)
+			if errA != nil {
+				return fail(errA)
+			}
+
+			b, errB := value(rows[j], item)
+			if errB != nil {
+				return fail(errB)
+			}
+
+			var result int
+
+			switch {
+			case a == nil && b == nil:
+				continue
+			case a == nil:
+				result = -1
+			case b == nil:
+				result = 1
+			default:
+				compared, compareErr := compareValues(a, b)
+				if compareErr != nil {
+					return fail(compareErr)
+				}
+
+				result = compared
+			}
+
+			if result == 0 {
+				continue
+			}
+
+			if item.descending {
+				return result > 0
+			}
+
+			return result < 0
+		}
+
+		return false
+	})
+
+	return
+}
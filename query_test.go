@@ -0,0 +1,171 @@
+package arrayfuncs_test
+
+import (
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type person struct {
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Team    string
+	Address *address
+}
+
+func TestQuery(t *testing.T) {
+	people := arrayFuncs.Array[person]{
+		{"carl", 45, "red", &address{"Lisbon"}},
+		{"ann", 31, "blue", &address{"Porto"}},
+		{"bob", 25, "red", nil},
+		{"dan", 60, "blue", &address{"Lisbon"}},
+	}
+
+	names := func(result *arrayFuncs.QueryResult) (res []any) {
+		for _, row := range result.Rows {
+			res = append(res, row["name"])
+		}
+
+		return
+	}
+
+	t.Run("SelectWhereOrderLimit", func(t *testing.T) {
+		result, err := arrayFuncs.Query(people, "SELECT name, age FROM . WHERE age > 30 ORDER BY name LIMIT 2")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "age"}, result.Columns)
+		assert.Equal(t, []map[string]any{
+			{"name": "ann", "age": 31},
+			{"name": "carl", "age": 45},
+		}, result.Rows)
+	})
+
+	t.Run("Conditions", func(t *testing.T) {
+		result, err := arrayFuncs.Query(people, `
+			SELECT name FROM .
+			WHERE (Team = 'red' OR address.city = "Porto") AND NOT age >= 45
+			ORDER BY age DESC`)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"ann", "bob"}, names(result))
+
+		result, err = arrayFuncs.Query(people, "SELECT name FROM . WHERE Address IS NULL")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"bob"}, names(result))
+	})
+
+	t.Run("QuotedStrings", func(t *testing.T) {
+		quoted := arrayFuncs.Array[person]{{"it's", 1, `say "hi"`, nil}}
+
+		result, err := arrayFuncs.Query(quoted, `SELECT age FROM . WHERE name = 'it''s' AND Team = "say ""hi"""`)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]any{{"age": 1}}, result.Rows)
+
+		result, err = arrayFuncs.Query(quoted, `SELECT age FROM . WHERE name = 'it\'s' AND Team = "say \"hi\""`)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]any{{"age": 1}}, result.Rows)
+	})
+
+	t.Run("OffsetAndStar", func(t *testing.T) {
+		result, err := arrayFuncs.Query(people, "SELECT * FROM . ORDER BY age OFFSET 3")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"name", "age", "Team", "Address"}, result.Columns)
+		assert.Equal(t, []any{"dan"}, names(result))
+	})
+
+	t.Run("HugeLimit", func(t *testing.T) {
+		result, err := arrayFuncs.Query(people, "SELECT name FROM . ORDER BY age LIMIT 9223372036854775807 OFFSET 1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []any{"ann", "carl", "dan"}, names(result))
+	})
+
+	t.Run("OrderByError", func(t *testing.T) {
+		type item struct {
+			Value any
+		}
+
+		items := arrayFuncs.Array[item]{{1}, {"a"}, {2}, {3}, {4}}
+
+		_, err := arrayFuncs.Query(items, "SELECT Value FROM . ORDER BY Value")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("GroupBy", func(t *testing.T) {
+		result, err := arrayFuncs.Query(people, `
+			SELECT team, COUNT(*) AS total, AVG(age) AS average, MAX(name) AS last
+			FROM . GROUP BY team ORDER BY average DESC`)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]any{
+			{"team": "blue", "total": 2, "average": 45.5, "last": "dan"},
+			{"team": "red", "total": 2, "average": 35.0, "last": "carl"},
+		}, result.Rows)
+
+		result, err = arrayFuncs.Query(people, "SELECT team, MIN(age) FROM . GROUP BY team ORDER BY COUNT(*) DESC, min(age)")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"team", "min(age)"}, result.Columns)
+		assert.Equal(t, []map[string]any{
+			{"team": "red", "min(age)": 25},
+			{"team": "blue", "min(age)": 31},
+		}, result.Rows)
+
+		result, err = arrayFuncs.Query(people, "SELECT team FROM . WHERE age > 26 GROUP BY team ORDER BY COUNT(*), team")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"team"}, result.Columns)
+		assert.Equal(t, []map[string]any{{"team": "red"}, {"team": "blue"}}, result.Rows)
+
+		result, err = arrayFuncs.Query(people, "SELECT SUM(age) FROM .")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []map[string]any{{"sum(age)": 161.0}}, result.Rows)
+	})
+
+	t.Run("QueryInto", func(t *testing.T) {
+		type summary struct {
+			Team  string
+			Total int `json:"total"`
+		}
+
+		res, err := arrayFuncs.QueryInto[person, summary](people, "SELECT team, COUNT(*) AS total FROM . GROUP BY team")
+
+		assert.Nil(t, err)
+		assert.Equal(t, arrayFuncs.Array[summary]{{"red", 2}, {"blue", 2}}, res)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		cases := map[string][2]int{
+			"SELECT name FROM people":                 {1, 18},
+			"SELECT name\nFROM . WHERE height > 1":    {2, 14},
+			"SELECT name FROM . WHERE age => 1":       {1, 30},
+			"SELECT name FROM . WHERE name = 'open":   {1, 33},
+			"SELECT name FROM .\nWHERE name = 'a\nb":  {2, 14},
+			"SELECT name FROM . LIMIT ten":            {1, 26},
+			"SELECT name, FROM .":                     {1, 14},
+			"SELECT name FROM . ORDER BY age ASC ASC": {1, 37},
+			"SELECT Team, name FROM . GROUP BY Team":  {1, 14},
+			"SELECT COUNT(*), age FROM .":             {1, 18},
+		}
+
+		for query, position := range cases {
+			_, err := arrayFuncs.Query(people, query)
+
+			queryErr, ok := err.(*arrayFuncs.QueryError)
+			if assert.True(t, ok, query) {
+				assert.Equal(t, position, [2]int{queryErr.Line, queryErr.Column}, query)
+			}
+		}
+	})
+}
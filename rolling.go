@@ -0,0 +1,259 @@
+package arrayfuncs
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+/*
+Scan is like Reduce, but it return every intermediate accumulator instead of only the last one.
+The element i of the result is the accumulator after the callback was called with the element i
+
+	a := Array[int]{1, 2, 3}
+	Scan(a, func(acc string, v int, i int) string { return acc + fmt.Sprint(v) }, "") // {"1", "12", "123"}
+*/
+func Scan[T, A comparable](arr Array[T], callback func(accumulator A, value T, index int) A, initialValue A) (res Array[A]) {
+	res = make(Array[A], len(arr))
+
+	accumulator := initialValue
+
+	for i, v := range arr {
+		accumulator = callback(accumulator, v, i)
+		res[i] = accumulator
+	}
+
+	return
+}
+
+// ScanRight is like Scan, but it goes from the last to the first element. The result keeps the positions of the elements
+func ScanRight[T, A comparable](arr Array[T], callback func(accumulator A, value T, index int) A, initialValue A) (res Array[A]) {
+	res = make(Array[A], len(arr))
+
+	accumulator := initialValue
+
+	for i := len(arr) - 1; i >= 0; i-- {
+		accumulator = callback(accumulator, arr[i], i)
+		res[i] = accumulator
+	}
+
+	return
+}
+
+// CumulativeSum return the sum of the elements until each position
+func CumulativeSum[T Number](arr Array[T]) (res Array[T]) {
+	return Scan(arr, func(accumulator, value T, _ int) T {
+		return accumulator + value
+	}, 0)
+}
+
+// checkWindow return an error when the window size is invalid
+func checkWindow(window int) error {
+	if window < 1 {
+		return fmt.Errorf("%w: window %d", ErrInvalidArgument, window)
+	}
+
+	return nil
+}
+
+// windowCount return how many complete windows the Array has
+func windowCount(length, window int) int {
+	if window > length {
+		return 0
+	}
+
+	return length - window + 1
+}
+
+// windowSum keep the running sum of a window. NaN and infinite values are counted apart,
+// because after they are added the sum can't return to a finite value by subtracting them.
+// The float types use Neumaier summation, so removing a big value doesn't lose the small ones added after it
+type windowSum[T Number] struct {
+	sum          T
+	isFloat      bool
+	floatSum     float64
+	compensation float64
+	nan          int
+	posInf       int
+	negInf       int
+}
+
+func newWindowSum[T Number]() *windowSum[T] {
+	kind := reflect.TypeFor[T]().Kind()
+
+	return &windowSum[T]{isFloat: kind == reflect.Float32 || kind == reflect.Float64}
+}
+
+// add add the value to the sum when sign is 1, or remove it when sign is -1
+func (w *windowSum[T]) add(v T, sign int) {
+	switch value := float64(v); {
+	case math.IsNaN(value):
+		w.nan += sign
+	case math.IsInf(value, 1):
+		w.posInf += sign
+	case math.IsInf(value, -1):
+		w.negInf += sign
+	case w.isFloat:
+		value *= float64(sign)
+		total := w.floatSum + value
+
+		// Keep the part of the smaller number that was lost on the addition
+		if math.Abs(w.floatSum) >= math.Abs(value) {
+			w.compensation += (w.floatSum - total) + value
+		} else {
+			w.compensation += (value - total) + w.floatSum
+		}
+
+		w.floatSum = total
+	case sign > 0:
+		w.sum += v
+	default:
+		w.sum -= v
+	}
+}
+
+func (w *windowSum[T]) value() T {
+	switch {
+	case w.nan > 0 || (w.posInf > 0 && w.negInf > 0):
+		return T(math.NaN())
+	case w.posInf > 0:
+		return T(math.Inf(1))
+	case w.negInf > 0:
+		return T(math.Inf(-1))
+	case w.isFloat:
+		return T(w.floatSum + w.compensation)
+	}
+
+	return w.sum
+}
+
+/*
+RollingSum return the sum of each window of consecutive elements, in O(n).
+Only the complete windows are returned, so the result has len(arr) - window + 1 elements
+
+	RollingSum(Array[int]{1, 2, 3, 4}, 2) // {3, 5, 7}
+*/
+func RollingSum[T Number](arr Array[T], window int) (res Array[T], err error) {
+	if err = checkWindow(window); err != nil {
+		return
+	}
+
+	res = make(Array[T], windowCount(len(arr), window))
+
+	sum := newWindowSum[T]()
+
+	for i, v := range arr {
+		sum.add(v, 1)
+
+		if i >= window {
+			sum.add(arr[i-window], -1)
+		}
+
+		if i >= window-1 {
+			res[i-window+1] = sum.value()
+		}
+	}
+
+	return
+}
+
+// RollingMean return the mean of each window of consecutive elements, like RollingSum
+func RollingMean[T Number](arr Array[T], window int) (res Array[float64], err error) {
+	if err = checkWindow(window); err != nil {
+		return
+	}
+
+	res = make(Array[float64], windowCount(len(arr), window))
+
+	sum := newWindowSum[float64]()
+
+	for i, v := range arr {
+		sum.add(float64(v), 1)
+
+		if i >= window {
+			sum.add(float64(arr[i-window]), -1)
+		}
+
+		if i >= window-1 {
+			res[i-window+1] = sum.value() / float64(window)
+		}
+	}
+
+	return
+}
+
+// RollingMin return the minimum of each window of consecutive elements, like RollingSum
+func RollingMin[T Number](arr Array[T], window int) (res Array[T], err error) {
+	return rollingExtreme(arr, window, func(a, b T) bool { return a <= b })
+}
+
+// RollingMax return the maximum of each window of consecutive elements, like RollingSum
+func RollingMax[T Number](arr Array[T], window int) (res Array[T], err error) {
+	return rollingExtreme(arr, window, func(a, b T) bool { return a >= b })
+}
+
+// rollingExtreme keep a monotonic deque of indexes, where the front is always the extreme of the window
+func rollingExtreme[T Number](arr Array[T], window int, keep func(a, b T) bool) (res Array[T], err error) {
+	if err = checkWindow(window); err != nil {
+		return
+	}
+
+	res = make(Array[T], windowCount(len(arr), window))
+
+	var (
+		deque = make([]int, 0, window)
+		front int
+	)
+
+	for i, v := range arr {
+		// Remove the index that left the window
+		if front < len(deque) && deque[front] <= i-window {
+			front++
+		}
+
+		// Remove the elements that can't be the extreme anymore
+		for len(deque) > front && keep(v, arr[deque[len(deque)-1]]) {
+			deque = deque[:len(deque)-1]
+		}
+
+		// Reuse the space of the removed front
+		if front > 0 && len(deque) == cap(deque) {
+			deque = append(deque[:0], deque[front:]...)
+			front = 0
+		}
+
+		deque = append(deque, i)
+
+		if i >= window-1 {
+			res[i-window+1] = arr[deque[front]]
+		}
+	}
+
+	return
+}
+
+/*
+EWMA return the exponentially weighted moving average of the elements.
+Each value is alpha * element + (1 - alpha) * previous value, starting with the first element.
+Alpha must be in (0, 1], a greater alpha gives more weight to the recent elements
+
+	EWMA(Array[int]{10, 20, 30}, 0.5) // {10, 15, 22.5}
+*/
+func EWMA[T Number](arr Array[T], alpha float64) (res Array[float64], err error) {
+	if alpha <= 0 || alpha > 1 {
+		return nil, fmt.Errorf("%w: alpha %v is out of (0, 1]", ErrInvalidArgument, alpha)
+	}
+
+	res = make(Array[float64], len(arr))
+
+	for i, v := range arr {
+		if i == 0 {
+			res[i] = float64(v)
+			continue
+		}
+
+		res[i] = alpha*float64(v) + (1-alpha)*res[i-1]
+	}
+
+	return
+}
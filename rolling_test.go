@@ -0,0 +1,144 @@
+package arrayfuncs_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolling(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{1, 2, 3}
+
+		concat := func(accumulator string, v int, _ int) string {
+			return accumulator + fmt.Sprint(v)
+		}
+
+		assert.Equal(t, arrayFuncs.Array[string]{"1", "12", "123"}, arrayFuncs.Scan(a, concat, ""))
+		assert.Equal(t, arrayFuncs.Array[string]{"321", "32", "3"}, arrayFuncs.ScanRight(a, concat, ""))
+		assert.Equal(t, arrayFuncs.Array[string]{}, arrayFuncs.Scan(arrayFuncs.Array[int]{}, concat, ""))
+	})
+
+	t.Run("CumulativeSum", func(t *testing.T) {
+		assert.Equal(t, arrayFuncs.Array[int]{1, 3, 6, 10}, arrayFuncs.CumulativeSum(arrayFuncs.Array[int]{1, 2, 3, 4}))
+		assert.Equal(t, arrayFuncs.Array[float64]{0.5, 0.75}, arrayFuncs.CumulativeSum(arrayFuncs.Array[float64]{0.5, 0.25}))
+	})
+
+	t.Run("RollingSumAndMean", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{1, 2, 3, 4}
+
+		sum, err := arrayFuncs.RollingSum(a, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{3, 5, 7}, sum)
+
+		mean, err := arrayFuncs.RollingMean(a, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{2, 3}, mean)
+
+		sum, err = arrayFuncs.RollingSum(a, 5)
+		assert.NoError(t, err)
+		assert.Empty(t, sum)
+
+		_, err = arrayFuncs.RollingMean(a, 0)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+	})
+
+	t.Run("RollingNonFinite", func(t *testing.T) {
+		a := arrayFuncs.Array[float64]{1, math.Inf(1), 1, 1, 1}
+
+		mean, err := arrayFuncs.RollingMean(a, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{math.Inf(1), math.Inf(1), 1, 1}, mean)
+
+		b := arrayFuncs.Array[float32]{1, float32(math.NaN()), float32(math.Inf(-1)), float32(math.Inf(1)), 2, 3}
+
+		sum, err := arrayFuncs.RollingSum(b, 2)
+		assert.NoError(t, err)
+		assert.True(t, math.IsNaN(float64(sum[0])))
+		assert.True(t, math.IsNaN(float64(sum[1])))
+		assert.True(t, math.IsNaN(float64(sum[2])))
+		assert.Equal(t, arrayFuncs.Array[float32]{float32(math.Inf(1)), 5}, sum[3:])
+	})
+
+	t.Run("RollingPrecision", func(t *testing.T) {
+		sum, err := arrayFuncs.RollingSum(arrayFuncs.Array[float64]{1e17, 1, 1, 1}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{1e17 + 1, 2, 2}, sum)
+
+		mean, err := arrayFuncs.RollingMean(arrayFuncs.Array[float64]{1e17, 1, 3, 5}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{5e16, 2, 4}, mean)
+
+		big, err := arrayFuncs.RollingSum(arrayFuncs.Array[int64]{1<<62 + 1, 1, 1}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int64]{1<<62 + 2, 2}, big)
+	})
+
+	t.Run("RollingMinAndMax", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{4, 2, 12, 3, 8, 8, 1, 5}
+
+		min, err := arrayFuncs.RollingMin(a, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{2, 2, 3, 3, 1, 1}, min)
+
+		max, err := arrayFuncs.RollingMax(a, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{12, 12, 12, 8, 8, 8}, max)
+
+		_, err = arrayFuncs.RollingMax(a, -1)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+	})
+
+	t.Run("RollingExtremesBruteForce", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+
+		a := make(arrayFuncs.Array[int], 500)
+		for i := range a {
+			a[i] = random.Intn(50)
+		}
+
+		for _, window := range []int{1, 2, 7, 64, 500} {
+			min, err := arrayFuncs.RollingMin(a, window)
+			assert.NoError(t, err)
+
+			max, err := arrayFuncs.RollingMax(a, window)
+			assert.NoError(t, err)
+
+			assert.Len(t, min, len(a)-window+1)
+
+			for i := range min {
+				expectedMin, expectedMax := a[i], a[i]
+
+				for _, v := range a[i : i+window] {
+					if v < expectedMin {
+						expectedMin = v
+					}
+
+					if v > expectedMax {
+						expectedMax = v
+					}
+				}
+
+				assert.Equal(t, expectedMin, min[i])
+				assert.Equal(t, expectedMax, max[i])
+			}
+		}
+	})
+
+	t.Run("EWMA", func(t *testing.T) {
+		res, err := arrayFuncs.EWMA(arrayFuncs.Array[int]{10, 20, 30}, 0.5)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{10, 15, 22.5}, res)
+
+		res, err = arrayFuncs.EWMA(arrayFuncs.Array[int]{10, 20}, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{10, 20}, res)
+
+		_, err = arrayFuncs.EWMA(arrayFuncs.Array[int]{10}, 0)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+	})
+}
@@ -0,0 +1,373 @@
+package arrayfuncs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrEmptyArray is returned when a statistic needs at least one element
+	ErrEmptyArray = errors.New("arrayfuncs: empty array")
+	// ErrLengthMismatch is returned when two Arrays must have the same length
+	ErrLengthMismatch = errors.New("arrayfuncs: arrays have different lengths")
+	// ErrInvalidArgument is returned when an argument is out of its valid range
+	ErrInvalidArgument = errors.New("arrayfuncs: invalid argument")
+	// ErrZeroVariance is returned when a statistic divides by a standard deviation of 0
+	ErrZeroVariance = errors.New("arrayfuncs: variance is zero")
+)
+
+// Number is the constraint of the numeric types
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Deviation choose if the variance is of the whole population or of a sample
+type Deviation int
+
+const (
+	// Population divide by n, the values are the whole population
+	Population Deviation = iota
+	// Sample divide by n - 1, the values are a sample of the population
+	Sample
+)
+
+// Interpolation choose how Percentile computes a value that is between two elements
+type Interpolation int
+
+const (
+	// Linear interpolates between the two elements, like Excel PERCENTILE.INC and numpy
+	Linear Interpolation = iota
+	// Lower takes the lower element
+	Lower
+	// Higher takes the higher element
+	Higher
+	// Nearest takes the nearest element, the even index on a tie
+	Nearest
+	// Midpoint takes the mean of the two elements
+	Midpoint
+)
+
+// HistogramResult has the edges of the bins and how many elements each one has. Edges has one more value than Counts
+type HistogramResult struct {
+	Edges  []float64
+	Counts []int
+}
+
+// checkFinite return ErrInvalidArgument with the index of the first NaN or infinite value
+func checkFinite[T Number](arr Array[T]) error {
+	for i, v := range arr {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return fmt.Errorf("%w: %v on index %d", ErrInvalidArgument, v, i)
+		}
+	}
+
+	return nil
+}
+
+// sortedFloats return the values as float64 sorted ascending, NaN and infinite values return ErrInvalidArgument
+func sortedFloats[T Number](arr Array[T]) (res []float64, err error) {
+	if err = checkFinite(arr); err != nil {
+		return nil, err
+	}
+
+	res = make([]float64, len(arr))
+	for i, v := range arr {
+		res[i] = float64(v)
+	}
+
+	sort.Float64s(res)
+
+	return
+}
+
+// welford return the mean and the sum of the squared differences from it, without losing precision on big values
+func welford[T Number](arr Array[T]) (mean, m2 float64) {
+	for i, v := range arr {
+		x := float64(v)
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+
+	return
+}
+
+// Mean return the arithmetic mean of the values
+func Mean[T Number](arr Array[T]) (res float64, err error) {
+	if len(arr) == 0 {
+		return 0, ErrEmptyArray
+	}
+
+	res, _ = welford(arr)
+
+	return
+}
+
+// Median return the middle value, or the mean of the two middle values when the length is even.
+// NaN and infinite values return ErrInvalidArgument
+func Median[T Number](arr Array[T]) (res float64, err error) {
+	return Percentile(arr, 50)
+}
+
+/*
+Percentile return the value below which p percent of the values are, with p from 0 to 100.
+The Interpolation is Linear by default. NaN and infinite values return ErrInvalidArgument
+
+	a := Array[int]{1, 2, 3, 4}
+	Percentile(a, 25)        // 1.75
+	Percentile(a, 25, Lower) // 1
+*/
+func Percentile[T Number](arr Array[T], p float64, interpolation ...Interpolation) (res float64, err error) {
+	if len(arr) == 0 {
+		return 0, ErrEmptyArray
+	}
+
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("%w: percentile %v is out of [0, 100]", ErrInvalidArgument, p)
+	}
+
+	method := Linear
+	if len(interpolation) > 0 {
+		method = interpolation[0]
+	}
+
+	sorted, err := sortedFloats(arr)
+	if err != nil {
+		return 0, err
+	}
+
+	return percentileOf(sorted, p, method), nil
+}
+
+// percentileOf compute the percentile on the sorted values
+func percentileOf(sorted []float64, p float64, method Interpolation) float64 {
+	var (
+		rank     = p / 100 * float64(len(sorted)-1)
+		lower    = int(math.Floor(rank))
+		higher   = int(math.Ceil(rank))
+		fraction = rank - float64(lower)
+	)
+
+	switch method {
+	case Lower:
+		return sorted[lower]
+	case Higher:
+		return sorted[higher]
+	case Nearest:
+		return sorted[int(math.RoundToEven(rank))]
+	case Midpoint:
+		return (sorted[lower] + sorted[higher]) / 2
+	default:
+		return sorted[lower] + fraction*(sorted[higher]-sorted[lower])
+	}
+}
+
+/*
+Quantiles return the n - 1 cut points that divide the values in n groups with the same size,
+using Linear interpolation. NaN and infinite values return ErrInvalidArgument
+
+	Quantiles(a, 4) // the quartiles
+*/
+func Quantiles[T Number](arr Array[T], n int) (res []float64, err error) {
+	if len(arr) == 0 {
+		return nil, ErrEmptyArray
+	}
+
+	if n < 1 {
+		return nil, fmt.Errorf("%w: %d quantiles", ErrInvalidArgument, n)
+	}
+
+	sorted, err := sortedFloats(arr)
+	if err != nil {
+		return nil, err
+	}
+
+	res = make([]float64, n-1)
+
+	for i := range res {
+		res[i] = percentileOf(sorted, float64(i+1)*100/float64(n), Linear)
+	}
+
+	return
+}
+
+// Mode return the values that appear the most times, sorted ascending. NaN and infinite values return ErrInvalidArgument
+func Mode[T Number](arr Array[T]) (res Array[T], err error) {
+	if len(arr) == 0 {
+		return nil, ErrEmptyArray
+	}
+
+	// NaN is never equal to itself, so it can't be counted on the map
+	if err = checkFinite(arr); err != nil {
+		return nil, err
+	}
+
+	var (
+		counts = make(map[T]int, len(arr))
+		max    int
+	)
+
+	for _, v := range arr {
+		counts[v]++
+
+		if counts[v] > max {
+			max = counts[v]
+		}
+	}
+
+	for _, v := range arr {
+		if counts[v] == max {
+			res = append(res, v)
+			counts[v] = 0
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+
+	return
+}
+
+// Variance return the variance of the values, computed with Welford's algorithm. The Deviation is Population by default
+func Variance[T Number](arr Array[T], deviation ...Deviation) (res float64, err error) {
+	if len(arr) == 0 {
+		return 0, ErrEmptyArray
+	}
+
+	_, m2 := welford(arr)
+
+	if len(deviation) > 0 && deviation[0] == Sample {
+		if len(arr) < 2 {
+			return 0, fmt.Errorf("%w: sample variance needs at least 2 values", ErrInvalidArgument)
+		}
+
+		return m2 / float64(len(arr)-1), nil
+	}
+
+	return m2 / float64(len(arr)), nil
+}
+
+// StdDev return the standard deviation of the values, the square root of Variance
+func StdDev[T Number](arr Array[T], deviation ...Deviation) (res float64, err error) {
+	if res, err = Variance(arr, deviation...); err != nil {
+		return
+	}
+
+	return math.Sqrt(res), nil
+}
+
+// ZScores return how many standard deviations each value is from the mean. The Deviation is Population by default
+func ZScores[T Number](arr Array[T], deviation ...Deviation) (res Array[float64], err error) {
+	std, err := StdDev(arr, deviation...)
+	if err != nil {
+		return
+	}
+
+	if std == 0 {
+		return nil, ErrZeroVariance
+	}
+
+	mean, _ := welford(arr)
+	res = make(Array[float64], len(arr))
+
+	for i, v := range arr {
+		res[i] = (float64(v) - mean) / std
+	}
+
+	return
+}
+
+/*
+Histogram count the values on bins with the same width, from the minimum to the maximum value.
+Each bin has its lower edge, the last one has both edges. NaN and infinite values return ErrInvalidArgument
+
+	Histogram(Array[int]{1, 2, 2, 3, 4}, 3) // Edges {1, 2, 3, 4}, Counts {1, 2, 2}
+*/
+func Histogram[T Number](arr Array[T], bins int) (res HistogramResult, err error) {
+	if len(arr) == 0 {
+		return res, ErrEmptyArray
+	}
+
+	if bins < 1 {
+		return res, fmt.Errorf("%w: %d bins", ErrInvalidArgument, bins)
+	}
+
+	if err = checkFinite(arr); err != nil {
+		return res, err
+	}
+
+	min, max := float64(arr[0]), float64(arr[0])
+
+	for _, v := range arr {
+		min = math.Min(min, float64(v))
+		max = math.Max(max, float64(v))
+	}
+
+	if math.IsInf(max-min, 0) {
+		return res, fmt.Errorf("%w: range from %v to %v is too big", ErrInvalidArgument, min, max)
+	}
+
+	// All the values are equal, use a range of 1 around them
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+
+	width := (max - min) / float64(bins)
+
+	res.Edges = make([]float64, bins+1)
+	for i := range res.Edges {
+		res.Edges[i] = min + float64(i)*width
+	}
+
+	res.Edges[bins] = max
+	res.Counts = make([]int, bins)
+
+	for _, v := range arr {
+		bin := int((float64(v) - min) / width)
+		if bin >= bins {
+			bin = bins - 1
+		}
+
+		res.Counts[bin]++
+	}
+
+	return
+}
+
+// Correlation return the Pearson correlation coefficient between the two Arrays, from -1 to 1
+func Correlation[T Number](a, b Array[T]) (res float64, err error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("%w: %d and %d", ErrLengthMismatch, len(a), len(b))
+	}
+
+	if len(a) == 0 {
+		return 0, ErrEmptyArray
+	}
+
+	// Welford's algorithm extended to the co-moment
+	var meanA, meanB, m2A, m2B, comoment float64
+
+	for i := range a {
+		var (
+			x, y   = float64(a[i]), float64(b[i])
+			n      = float64(i + 1)
+			deltaA = x - meanA
+			deltaB = y - meanB
+		)
+
+		meanA += deltaA / n
+		meanB += deltaB / n
+		m2A += deltaA * (x - meanA)
+		m2B += deltaB * (y - meanB)
+		comoment += deltaA * (y - meanB)
+	}
+
+	if m2A == 0 || m2B == 0 {
+		return 0, ErrZeroVariance
+	}
+
+	return comoment / math.Sqrt(m2A*m2B), nil
+}
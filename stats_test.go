@@ -0,0 +1,198 @@
+package arrayfuncs_test
+
+import (
+	"math"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+// anscombeX and anscombeY are the first set of the Anscombe's quartet
+var (
+	anscombeX = arrayFuncs.Array[float64]{10, 8, 13, 9, 11, 14, 6, 4, 12, 7, 5}                              //nolint:gochecknoglobals
+	anscombeY = arrayFuncs.Array[float64]{8.04, 6.95, 7.58, 8.81, 8.33, 9.96, 7.24, 4.26, 10.84, 4.82, 5.68} //nolint:gochecknoglobals
+)
+
+func TestStats(t *testing.T) {
+	t.Run("NISTNumAcc", func(t *testing.T) {
+		numAcc1 := arrayFuncs.Array[int64]{10000001, 10000003, 10000002}
+
+		mean, err := arrayFuncs.Mean(numAcc1)
+		assert.NoError(t, err)
+		assert.Equal(t, 10000002.0, mean)
+
+		std, err := arrayFuncs.StdDev(numAcc1, arrayFuncs.Sample)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0, std, 1e-12)
+
+		numAcc3 := arrayFuncs.Array[float64]{1000000.2}
+		for i := 0; i < 500; i++ {
+			numAcc3 = append(numAcc3, 1000000.1, 1000000.3)
+		}
+
+		mean, err = arrayFuncs.Mean(numAcc3)
+		assert.NoError(t, err)
+		assert.InDelta(t, 1000000.2, mean, 1e-9)
+
+		std, err = arrayFuncs.StdDev(numAcc3, arrayFuncs.Sample)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.1, std, 1e-9)
+	})
+
+	t.Run("Variance", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{2, 4, 4, 4, 5, 5, 7, 9}
+
+		variance, err := arrayFuncs.Variance(a)
+		assert.NoError(t, err)
+		assert.Equal(t, 4.0, variance)
+
+		std, err := arrayFuncs.StdDev(a)
+		assert.NoError(t, err)
+		assert.Equal(t, 2.0, std)
+
+		variance, err = arrayFuncs.Variance(a, arrayFuncs.Sample)
+		assert.NoError(t, err)
+		assert.InDelta(t, 32.0/7, variance, 1e-12)
+
+		_, err = arrayFuncs.Variance(arrayFuncs.Array[int]{1}, arrayFuncs.Sample)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+
+		_, err = arrayFuncs.Variance(arrayFuncs.Array[int]{})
+		assert.ErrorIs(t, err, arrayFuncs.ErrEmptyArray)
+	})
+
+	t.Run("Median", func(t *testing.T) {
+		median, err := arrayFuncs.Median(arrayFuncs.Array[int]{5, 1, 3})
+		assert.NoError(t, err)
+		assert.Equal(t, 3.0, median)
+
+		median, err = arrayFuncs.Median(arrayFuncs.Array[uint8]{4, 1, 3, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 2.5, median)
+	})
+
+	t.Run("PercentileInterpolations", func(t *testing.T) {
+		a := arrayFuncs.Array[int]{4, 2, 3, 1}
+
+		expected := map[arrayFuncs.Interpolation]float64{
+			arrayFuncs.Linear:   1.75,
+			arrayFuncs.Lower:    1,
+			arrayFuncs.Higher:   2,
+			arrayFuncs.Nearest:  2,
+			arrayFuncs.Midpoint: 1.5,
+		}
+
+		for method, value := range expected {
+			res, err := arrayFuncs.Percentile(a, 25, method)
+			assert.NoError(t, err)
+			assert.Equal(t, value, res, "method %d", method)
+		}
+
+		res, err := arrayFuncs.Percentile(a, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, 4.0, res)
+
+		_, err = arrayFuncs.Percentile(a, 101)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+	})
+
+	t.Run("Quantiles", func(t *testing.T) {
+		res, err := arrayFuncs.Quantiles(arrayFuncs.Array[int]{1, 2, 3, 4, 5, 6, 7, 8, 9}, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{3, 5, 7}, res)
+
+		res, err = arrayFuncs.Quantiles(arrayFuncs.Array[int]{1, 2}, 1)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+
+		_, err = arrayFuncs.Quantiles(arrayFuncs.Array[int]{1, 2}, 0)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+	})
+
+	t.Run("Mode", func(t *testing.T) {
+		res, err := arrayFuncs.Mode(arrayFuncs.Array[int]{3, 1, 3, 2, 1, 5})
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{1, 3}, res)
+
+		single, err := arrayFuncs.Mode(arrayFuncs.Array[float64]{2.5})
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{2.5}, single)
+	})
+
+	t.Run("NonFinite", func(t *testing.T) {
+		for _, values := range []arrayFuncs.Array[float64]{
+			{3, math.NaN(), 1},
+			{math.NaN(), math.NaN()},
+			{1, math.Inf(1)},
+			{math.Inf(-1), 1},
+		} {
+			_, err := arrayFuncs.Median(values)
+			assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument, values)
+
+			_, err = arrayFuncs.Percentile(values, 90, arrayFuncs.Lower)
+			assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument, values)
+
+			_, err = arrayFuncs.Quantiles(values, 4)
+			assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument, values)
+
+			_, err = arrayFuncs.Mode(values)
+			assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument, values)
+		}
+	})
+
+	t.Run("Histogram", func(t *testing.T) {
+		res, err := arrayFuncs.Histogram(arrayFuncs.Array[int]{1, 2, 2, 3, 4}, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 2}, res.Counts)
+		assert.InDeltaSlice(t, []float64{1, 2, 3, 4}, res.Edges, 1e-12)
+
+		res, err = arrayFuncs.Histogram(arrayFuncs.Array[int]{7, 7}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 2}, res.Counts)
+		assert.Equal(t, []float64{6.5, 7, 7.5}, res.Edges)
+
+		_, err = arrayFuncs.Histogram(arrayFuncs.Array[int]{1}, 0)
+		assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument)
+
+		for _, values := range []arrayFuncs.Array[float64]{
+			{1, math.NaN(), 3},
+			{1, math.Inf(1)},
+			{math.Inf(-1), 1},
+			{-math.MaxFloat64, math.MaxFloat64},
+		} {
+			_, err = arrayFuncs.Histogram(values, 2)
+			assert.ErrorIs(t, err, arrayFuncs.ErrInvalidArgument, values)
+		}
+	})
+
+	t.Run("ZScores", func(t *testing.T) {
+		res, err := arrayFuncs.ZScores(arrayFuncs.Array[int]{2, 4, 4, 4, 5, 5, 7, 9})
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[float64]{-1.5, -0.5, -0.5, -0.5, 0, 0, 1, 2}, res)
+
+		_, err = arrayFuncs.ZScores(arrayFuncs.Array[int]{3, 3})
+		assert.ErrorIs(t, err, arrayFuncs.ErrZeroVariance)
+	})
+
+	t.Run("CorrelationAnscombe", func(t *testing.T) {
+		res, err := arrayFuncs.Correlation(anscombeX, anscombeY)
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.81642, res, 1e-5)
+
+		mean, err := arrayFuncs.Mean(anscombeY)
+		assert.NoError(t, err)
+		assert.InDelta(t, 7.50091, mean, 1e-5)
+
+		variance, err := arrayFuncs.Variance(anscombeY, arrayFuncs.Sample)
+		assert.NoError(t, err)
+		assert.InDelta(t, 4.12727, variance, 1e-5)
+
+		res, err = arrayFuncs.Correlation(arrayFuncs.Array[int]{1, 2, 3}, arrayFuncs.Array[int]{6, 4, 2})
+		assert.NoError(t, err)
+		assert.InDelta(t, -1.0, res, 1e-12)
+
+		_, err = arrayFuncs.Correlation(arrayFuncs.Array[int]{1, 2}, arrayFuncs.Array[int]{1})
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+	})
+}
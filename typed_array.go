@@ -0,0 +1,529 @@
+package arrayfuncs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"unsafe"
+)
+
+var (
+	// ErrOutOfRange is returned when an offset or length is out of the ArrayBuffer
+	ErrOutOfRange = errors.New("arrayfuncs: out of range")
+	// ErrMisaligned is returned when the byte offset of a typed array isn't a multiple of its element size
+	ErrMisaligned = errors.New("arrayfuncs: misaligned offset")
+)
+
+/*
+ArrayBuffer is a fixed length block of bytes, like the JavaScript ArrayBuffer.
+Its content is read and written with typed arrays and DataViews, that can share the same ArrayBuffer
+
+	buffer := NewArrayBuffer(8)
+	words := NewTypedArrayOn[int32](buffer, 0)    // 2 elements
+	bytes := NewTypedArrayOn[uint8](buffer, 4, 4) // the bytes of the second element
+*/
+type ArrayBuffer struct {
+	data []byte
+}
+
+// NewArrayBuffer create an ArrayBuffer with byteLength zero bytes
+func NewArrayBuffer(byteLength int) *ArrayBuffer {
+	if byteLength < 0 {
+		byteLength = 0
+	}
+
+	// The bytes are allocated as words so any typed array on them is aligned
+	words := make([]uint64, (byteLength+7)/8)
+
+	var data []byte
+	if len(words) > 0 {
+		data = unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), byteLength)
+	}
+
+	return &ArrayBuffer{data: data}
+}
+
+// ByteLength return the size of the ArrayBuffer in bytes
+func (b *ArrayBuffer) ByteLength() int {
+	return len(b.data)
+}
+
+// Bytes return the content of the ArrayBuffer, sharing its memory
+func (b *ArrayBuffer) Bytes() []byte {
+	return b.data
+}
+
+// Slice return a new ArrayBuffer with a copy of the bytes from start to end, without the end. Negative indexes count from the end
+func (b *ArrayBuffer) Slice(start int, end ...int) *ArrayBuffer {
+	stop := len(b.data)
+	if len(end) > 0 {
+		stop = end[0]
+	}
+
+	start, stop = clampRange(start, stop, len(b.data))
+
+	res := NewArrayBuffer(stop - start)
+	copy(res.data, b.data[start:stop])
+
+	return res
+}
+
+// TypedElement is the constraint of the elements of the typed arrays
+type TypedElement interface {
+	int8 | uint8 | int16 | uint16 | int32 | uint32 | int64 | uint64 | float32 | float64
+}
+
+/*
+TypedArray is a view of an ArrayBuffer as an array of numbers, like the JavaScript typed arrays.
+The elements use the byte order of the machine, like JavaScript does, so use a DataView to read a specific byte order.
+All the views on the same ArrayBuffer see the changes of each other
+
+	numbers := NewTypedArray[float64](4)
+	numbers.Fill(1.5, 0)
+	numbers.Values()[0] = 2
+*/
+type TypedArray[T TypedElement] struct {
+	buffer     *ArrayBuffer
+	byteOffset int
+	values     Array[T]
+}
+
+type (
+	// Int8Array is the Go version of the JavaScript Int8Array
+	Int8Array = TypedArray[int8]
+	// Uint8Array is the Go version of the JavaScript Uint8Array
+	Uint8Array = TypedArray[uint8]
+	// Int16Array is the Go version of the JavaScript Int16Array
+	Int16Array = TypedArray[int16]
+	// Uint16Array is the Go version of the JavaScript Uint16Array
+	Uint16Array = TypedArray[uint16]
+	// Int32Array is the Go version of the JavaScript Int32Array
+	Int32Array = TypedArray[int32]
+	// Uint32Array is the Go version of the JavaScript Uint32Array
+	Uint32Array = TypedArray[uint32]
+	// Float32Array is the Go version of the JavaScript Float32Array
+	Float32Array = TypedArray[float32]
+	// Float64Array is the Go version of the JavaScript Float64Array
+	Float64Array = TypedArray[float64]
+	// BigInt64Array is the Go version of the JavaScript BigInt64Array
+	BigInt64Array = TypedArray[int64]
+	// BigUint64Array is the Go version of the JavaScript BigUint64Array
+	BigUint64Array = TypedArray[uint64]
+)
+
+// NewTypedArray create a typed array with length elements on a new ArrayBuffer
+func NewTypedArray[T TypedElement](length int) *TypedArray[T] {
+	var zero T
+
+	if length < 0 {
+		length = 0
+	}
+
+	res, _ := NewTypedArrayOn[T](NewArrayBuffer(length*int(unsafe.Sizeof(zero))), 0)
+
+	return res
+}
+
+// TypedArrayFrom create a typed array with a copy of the values on a new ArrayBuffer
+func TypedArrayFrom[T TypedElement](values ...T) *TypedArray[T] {
+	res := NewTypedArray[T](len(values))
+	copy(res.values, values)
+
+	return res
+}
+
+/*
+NewTypedArrayOn create a typed array on the buffer, starting on byteOffset.
+Without length the typed array goes until the end of the buffer.
+The byteOffset must be a multiple of the element size, like on JavaScript
+*/
+func NewTypedArrayOn[T TypedElement](buffer *ArrayBuffer, byteOffset int, length ...int) (res *TypedArray[T], err error) {
+	var (
+		zero T
+		size = int(unsafe.Sizeof(zero))
+	)
+
+	if byteOffset < 0 || byteOffset > len(buffer.data) {
+		return nil, fmt.Errorf("%w: byte offset %d", ErrOutOfRange, byteOffset)
+	}
+
+	if byteOffset%size != 0 {
+		return nil, fmt.Errorf("%w: byte offset %d isn't a multiple of %d", ErrMisaligned, byteOffset, size)
+	}
+
+	count := (len(buffer.data) - byteOffset) / size
+
+	if len(length) > 0 {
+		if length[0] < 0 || length[0] > count {
+			return nil, fmt.Errorf("%w: length %d", ErrOutOfRange, length[0])
+		}
+
+		count = length[0]
+	} else if (len(buffer.data)-byteOffset)%size != 0 {
+		return nil, fmt.Errorf("%w: buffer length isn't a multiple of %d", ErrMisaligned, size)
+	}
+
+	res = &TypedArray[T]{buffer: buffer, byteOffset: byteOffset, values: Array[T]{}}
+
+	if count > 0 {
+		res.values = unsafe.Slice((*T)(unsafe.Pointer(&buffer.data[byteOffset])), count)
+	}
+
+	return
+}
+
+// Buffer return the ArrayBuffer of the typed array
+func (a *TypedArray[T]) Buffer() *ArrayBuffer {
+	return a.buffer
+}
+
+// ByteOffset return where the typed array starts on its ArrayBuffer
+func (a *TypedArray[T]) ByteOffset() int {
+	return a.byteOffset
+}
+
+// ByteLength return the size of the typed array in bytes
+func (a *TypedArray[T]) ByteLength() int {
+	var zero T
+
+	return len(a.values) * int(unsafe.Sizeof(zero))
+}
+
+// Len return the count of elements
+func (a *TypedArray[T]) Len() int {
+	return len(a.values)
+}
+
+// Values return the elements as an Array that shares the ArrayBuffer, changing it changes the typed array
+func (a *TypedArray[T]) Values() Array[T] {
+	return a.values[:len(a.values):len(a.values)]
+}
+
+// ToArray return a copy of the elements
+func (a *TypedArray[T]) ToArray() (res Array[T]) {
+	res = make(Array[T], len(a.values))
+	copy(res, a.values)
+
+	return
+}
+
+// At return the pointer to the element, like Array.At
+func (a *TypedArray[T]) At(index int) (res *T) {
+	index, ok := normalizeIndex(index, len(a.values))
+	if ok {
+		res = &a.values[index]
+	}
+
+	return
+}
+
+// Set change the element on the index, return false if it doesn't exist
+func (a *TypedArray[T]) Set(index int, value T) bool {
+	index, ok := normalizeIndex(index, len(a.values))
+	if ok {
+		a.values[index] = value
+	}
+
+	return ok
+}
+
+// Subarray return a typed array on the same ArrayBuffer, from start to end without the end. Negative indexes count from the end
+func (a *TypedArray[T]) Subarray(start int, end ...int) *TypedArray[T] {
+	var zero T
+
+	stop := len(a.values)
+	if len(end) > 0 {
+		stop = end[0]
+	}
+
+	start, stop = clampRange(start, stop, len(a.values))
+
+	return &TypedArray[T]{
+		buffer:     a.buffer,
+		byteOffset: a.byteOffset + start*int(unsafe.Sizeof(zero)),
+		values:     a.values[start:stop:stop],
+	}
+}
+
+// Slice return a typed array with a copy of the elements from start to end on a new ArrayBuffer, like Subarray
+func (a *TypedArray[T]) Slice(start int, end ...int) *TypedArray[T] {
+	return TypedArrayFrom(a.Subarray(start, end...).values...)
+}
+
+/*
+Fill set the value on the elements from start until the end, including the end like Array.Fill.
+Negative indexes count from the end and the range is limited to the length, like JavaScript
+
+	a := TypedArrayFrom[int8](1, 2, 3, 4)
+	a.Fill(0, -2)     // {1, 2, 0, 0}
+	a.Fill(9, -10, 0) // {9, 2, 0, 0}
+*/
+func (a *TypedArray[T]) Fill(value T, start int, end ...int) *TypedArray[T] {
+	length := len(a.values)
+
+	// stop is the index after the last one filled
+	stop := length
+	if len(end) > 0 {
+		if stop = end[0]; stop < 0 {
+			stop += length
+		}
+
+		stop = max(stop+1, 0)
+	}
+
+	if start, stop = clampRange(start, stop, length); start < stop {
+		a.values.Fill(value, start, stop-1)
+	}
+
+	return a
+}
+
+// IndexOf return the first index of the value or -1 if not found, like Array.IndexOf
+func (a *TypedArray[T]) IndexOf(value T) int {
+	return a.values.IndexOf(value)
+}
+
+// Map call the callback with each element, changing the elements like Array.Map
+func (a *TypedArray[T]) Map(callback func(v *T, i int)) {
+	a.values.Map(callback)
+}
+
+// Sort sorts the elements like Array.Sort. When the callback is nil they are sorted by their numeric value with NaN at the end, like JavaScript
+func (a *TypedArray[T]) Sort(callback func(index1, index2 int) bool) {
+	if callback == nil {
+		values := a.values
+
+		callback = func(i, j int) bool {
+			return values[i] < values[j] || values[i] == values[i] && values[j] != values[j]
+		}
+	}
+
+	sort.SliceStable(a.values, callback)
+}
+
+/*
+DataView read and write numbers of any type on any offset of an ArrayBuffer, like the JavaScript DataView.
+The numbers are big endian unless littleEndian is passed as true, like on JavaScript
+
+	view, _ := NewDataView(buffer, 0)
+	view.SetUint16(0, 0xCAFE)
+	v, err := view.GetUint16(0, true) // 0xFECA
+*/
+type DataView struct {
+	buffer     *ArrayBuffer
+	byteOffset int
+	data       []byte
+}
+
+// NewDataView create a DataView on the buffer, from byteOffset until byteLength bytes or the end of the buffer
+func NewDataView(buffer *ArrayBuffer, byteOffset int, byteLength ...int) (res *DataView, err error) {
+	if byteOffset < 0 || byteOffset > len(buffer.data) {
+		return nil, fmt.Errorf("%w: byte offset %d", ErrOutOfRange, byteOffset)
+	}
+
+	end := len(buffer.data)
+
+	if len(byteLength) > 0 {
+		if byteLength[0] < 0 || byteOffset+byteLength[0] > end {
+			return nil, fmt.Errorf("%w: byte length %d", ErrOutOfRange, byteLength[0])
+		}
+
+		end = byteOffset + byteLength[0]
+	}
+
+	return &DataView{buffer: buffer, byteOffset: byteOffset, data: buffer.data[byteOffset:end:end]}, nil
+}
+
+// Buffer return the ArrayBuffer of the DataView
+func (v *DataView) Buffer() *ArrayBuffer {
+	return v.buffer
+}
+
+// ByteOffset return where the DataView starts on its ArrayBuffer
+func (v *DataView) ByteOffset() int {
+	return v.byteOffset
+}
+
+// ByteLength return the size of the DataView in bytes
+func (v *DataView) ByteLength() int {
+	return len(v.data)
+}
+
+// bytes return the size bytes on the offset, or an error if they are out of the view
+func (v *DataView) bytes(offset, size int) ([]byte, error) {
+	if offset < 0 || offset+size > len(v.data) {
+		return nil, fmt.Errorf("%w: offset %d with %d bytes on a view of %d bytes", ErrOutOfRange, offset, size, len(v.data))
+	}
+
+	return v.data[offset : offset+size], nil
+}
+
+func byteOrder(littleEndian []bool) binary.ByteOrder {
+	if len(littleEndian) > 0 && littleEndian[0] {
+		return binary.LittleEndian
+	}
+
+	return binary.BigEndian
+}
+
+// GetInt8 read a int8 on the offset
+func (v *DataView) GetInt8(offset int) (int8, error) {
+	value, err := v.GetUint8(offset)
+
+	return int8(value), err
+}
+
+// GetUint8 read a uint8 on the offset
+func (v *DataView) GetUint8(offset int) (uint8, error) {
+	b, err := v.bytes(offset, 1)
+	if err != nil {
+		return 0, err
+	}
+
+	return b[0], nil
+}
+
+// GetInt16 read a int16 on the offset
+func (v *DataView) GetInt16(offset int, littleEndian ...bool) (int16, error) {
+	value, err := v.GetUint16(offset, littleEndian...)
+
+	return int16(value), err
+}
+
+// GetUint16 read a uint16 on the offset
+func (v *DataView) GetUint16(offset int, littleEndian ...bool) (uint16, error) {
+	b, err := v.bytes(offset, 2)
+	if err != nil {
+		return 0, err
+	}
+
+	return byteOrder(littleEndian).Uint16(b), nil
+}
+
+// GetInt32 read a int32 on the offset
+func (v *DataView) GetInt32(offset int, littleEndian ...bool) (int32, error) {
+	value, err := v.GetUint32(offset, littleEndian...)
+
+	return int32(value), err
+}
+
+// GetUint32 read a uint32 on the offset
+func (v *DataView) GetUint32(offset int, littleEndian ...bool) (uint32, error) {
+	b, err := v.bytes(offset, 4)
+	if err != nil {
+		return 0, err
+	}
+
+	return byteOrder(littleEndian).Uint32(b), nil
+}
+
+// GetBigInt64 read a int64 on the offset
+func (v *DataView) GetBigInt64(offset int, littleEndian ...bool) (int64, error) {
+	value, err := v.GetBigUint64(offset, littleEndian...)
+
+	return int64(value), err
+}
+
+// GetBigUint64 read a uint64 on the offset
+func (v *DataView) GetBigUint64(offset int, littleEndian ...bool) (uint64, error) {
+	b, err := v.bytes(offset, 8)
+	if err != nil {
+		return 0, err
+	}
+
+	return byteOrder(littleEndian).Uint64(b), nil
+}
+
+// GetFloat32 read a float32 on the offset
+func (v *DataView) GetFloat32(offset int, littleEndian ...bool) (float32, error) {
+	value, err := v.GetUint32(offset, littleEndian...)
+
+	return math.Float32frombits(value), err
+}
+
+// GetFloat64 read a float64 on the offset
+func (v *DataView) GetFloat64(offset int, littleEndian ...bool) (float64, error) {
+	value, err := v.GetBigUint64(offset, littleEndian...)
+
+	return math.Float64frombits(value), err
+}
+
+// SetInt8 write a int8 on the offset
+func (v *DataView) SetInt8(offset int, value int8) error {
+	return v.SetUint8(offset, uint8(value))
+}
+
+// SetUint8 write a uint8 on the offset
+func (v *DataView) SetUint8(offset int, value uint8) error {
+	b, err := v.bytes(offset, 1)
+	if err != nil {
+		return err
+	}
+
+	b[0] = value
+
+	return nil
+}
+
+// SetInt16 write a int16 on the offset
+func (v *DataView) SetInt16(offset int, value int16, littleEndian ...bool) error {
+	return v.SetUint16(offset, uint16(value), littleEndian...)
+}
+
+// SetUint16 write a uint16 on the offset
+func (v *DataView) SetUint16(offset int, value uint16, littleEndian ...bool) error {
+	b, err := v.bytes(offset, 2)
+	if err != nil {
+		return err
+	}
+
+	byteOrder(littleEndian).PutUint16(b, value)
+
+	return nil
+}
+
+// SetInt32 write a int32 on the offset
+func (v *DataView) SetInt32(offset int, value int32, littleEndian ...bool) error {
+	return v.SetUint32(offset, uint32(value), littleEndian...)
+}
+
+// SetUint32 write a uint32 on the offset
+func (v *DataView) SetUint32(offset int, value uint32, littleEndian ...bool) error {
+	b, err := v.bytes(offset, 4)
+	if err != nil {
+		return err
+	}
+
+	byteOrder(littleEndian).PutUint32(b, value)
+
+	return nil
+}
+
+// SetBigInt64 write a int64 on the offset
+func (v *DataView) SetBigInt64(offset int, value int64, littleEndian ...bool) error {
+	return v.SetBigUint64(offset, uint64(value), littleEndian...)
+}
+
+// SetBigUint64 write a uint64 on the offset
+func (v *DataView) SetBigUint64(offset int, value uint64, littleEndian ...bool) error {
+	b, err := v.bytes(offset, 8)
+	if err != nil {
+		return err
+	}
+
+	byteOrder(littleEndian).PutUint64(b, value)
+
+	return nil
+}
+
+// SetFloat32 write a float32 on the offset
+func (v *DataView) SetFloat32(offset int, value float32, littleEndian ...bool) error {
+	return v.SetUint32(offset, math.Float32bits(value), littleEndian...)
+}
+
+// SetFloat64 write a float64 on the offset
+func (v *DataView) SetFloat64(offset int, value float64, littleEndian ...bool) error {
+	return v.SetBigUint64(offset, math.Float64bits(value), littleEndian...)
+}
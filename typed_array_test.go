@@ -0,0 +1,183 @@
+package arrayfuncs_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedArray(t *testing.T) {
+	t.Run("SharedBuffer", func(t *testing.T) {
+		buffer := arrayFuncs.NewArrayBuffer(8)
+
+		words, err := arrayFuncs.NewTypedArrayOn[int32](buffer, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, words.Len())
+
+		bytes, err := arrayFuncs.NewTypedArrayOn[uint8](buffer, 4, 4)
+		assert.NoError(t, err)
+
+		words.Set(1, 0x01020304)
+
+		expected := make([]byte, 4)
+		binary.NativeEndian.PutUint32(expected, 0x01020304)
+		assert.Equal(t, arrayFuncs.Array[uint8](expected), bytes.ToArray())
+
+		bytes.Fill(0xff, 0)
+		assert.Equal(t, int32(-1), *words.At(-1))
+		assert.Equal(t, 8, buffer.ByteLength())
+	})
+
+	t.Run("InvalidRanges", func(t *testing.T) {
+		buffer := arrayFuncs.NewArrayBuffer(10)
+
+		_, err := arrayFuncs.NewTypedArrayOn[int32](buffer, 2)
+		assert.ErrorIs(t, err, arrayFuncs.ErrMisaligned)
+
+		_, err = arrayFuncs.NewTypedArrayOn[int32](buffer, 4)
+		assert.ErrorIs(t, err, arrayFuncs.ErrMisaligned)
+
+		_, err = arrayFuncs.NewTypedArrayOn[int16](buffer, 12)
+		assert.ErrorIs(t, err, arrayFuncs.ErrOutOfRange)
+
+		_, err = arrayFuncs.NewTypedArrayOn[float64](buffer, 0, 2)
+		assert.ErrorIs(t, err, arrayFuncs.ErrOutOfRange)
+
+		view, err := arrayFuncs.NewTypedArrayOn[float64](buffer, 8, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, view.Len())
+	})
+
+	t.Run("SubarrayAndSlice", func(t *testing.T) {
+		numbers := arrayFuncs.TypedArrayFrom[float64](1, 2, 3, 4, 5)
+
+		sub := numbers.Subarray(1, -1)
+		assert.Equal(t, arrayFuncs.Array[float64]{2, 3, 4}, sub.ToArray())
+		assert.Equal(t, 8, sub.ByteOffset())
+		assert.Equal(t, 24, sub.ByteLength())
+		assert.Same(t, numbers.Buffer(), sub.Buffer())
+
+		sub.Set(0, 20)
+		assert.Equal(t, 20.0, *numbers.At(1))
+
+		copied := numbers.Slice(-2)
+		copied.Set(0, 40)
+		assert.Equal(t, arrayFuncs.Array[float64]{40, 5}, copied.ToArray())
+		assert.Equal(t, 4.0, *numbers.At(3))
+		assert.NotSame(t, numbers.Buffer(), copied.Buffer())
+	})
+
+	t.Run("ArrayMethods", func(t *testing.T) {
+		numbers := arrayFuncs.TypedArrayFrom[int16](30, -10, 20)
+
+		assert.Equal(t, 2, numbers.IndexOf(20))
+		assert.Equal(t, -1, numbers.IndexOf(7))
+
+		numbers.Map(func(v *int16, i int) { *v += int16(i) })
+		assert.Equal(t, arrayFuncs.Array[int16]{30, -9, 22}, numbers.ToArray())
+
+		numbers.Sort(nil)
+		assert.Equal(t, arrayFuncs.Array[int16]{-9, 22, 30}, numbers.ToArray())
+
+		values := numbers.Values()
+		numbers.Sort(func(i, j int) bool { return values[i] > values[j] })
+		assert.Equal(t, arrayFuncs.Array[int16]{30, 22, -9}, values)
+
+		floats := arrayFuncs.TypedArrayFrom[float32](2, float32(math.NaN()), -1)
+		floats.Sort(nil)
+		assert.Equal(t, float32(-1), *floats.At(0))
+		assert.True(t, math.IsNaN(float64(*floats.At(2))))
+	})
+
+	t.Run("FillRanges", func(t *testing.T) {
+		numbers := arrayFuncs.NewTypedArray[int8](5)
+
+		numbers.Fill(1, -2)
+		assert.Equal(t, arrayFuncs.Array[int8]{0, 0, 0, 1, 1}, numbers.ToArray())
+
+		numbers.Fill(2, -10, 1)
+		assert.Equal(t, arrayFuncs.Array[int8]{2, 2, 0, 1, 1}, numbers.ToArray())
+
+		numbers.Fill(3, 1, -3)
+		assert.Equal(t, arrayFuncs.Array[int8]{2, 3, 3, 1, 1}, numbers.ToArray())
+
+		numbers.Fill(4, 10)
+		numbers.Fill(4, 0, -10)
+		numbers.Fill(4, 3, 1)
+		assert.Equal(t, arrayFuncs.Array[int8]{2, 3, 3, 1, 1}, numbers.ToArray())
+
+		numbers.Fill(5, -5, 100)
+		assert.Equal(t, arrayFuncs.Array[int8]{5, 5, 5, 5, 5}, numbers.ToArray())
+	})
+
+	t.Run("DataViewEndianness", func(t *testing.T) {
+		buffer := arrayFuncs.NewArrayBuffer(16)
+
+		view, err := arrayFuncs.NewDataView(buffer, 0)
+		assert.NoError(t, err)
+
+		assert.NoError(t, view.SetUint16(0, 0xCAFE))
+		assert.Equal(t, []byte{0xCA, 0xFE}, buffer.Bytes()[:2])
+
+		value, err := view.GetUint16(0, true)
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(0xFECA), value)
+
+		assert.NoError(t, view.SetInt32(2, -2, true))
+		number, err := view.GetInt32(2, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(-2), number)
+
+		assert.NoError(t, view.SetFloat64(8, math.Pi))
+		float, err := view.GetFloat64(8)
+		assert.NoError(t, err)
+		assert.Equal(t, math.Pi, float)
+
+		assert.NoError(t, view.SetBigInt64(8, math.MinInt64, true))
+		big, err := view.GetBigInt64(8, true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(math.MinInt64), big)
+
+		assert.NoError(t, view.SetFloat32(4, 1.5))
+		small, err := view.GetFloat32(4)
+		assert.NoError(t, err)
+		assert.Equal(t, float32(1.5), small)
+
+		assert.NoError(t, view.SetInt8(0, -1))
+		signed, err := view.GetInt8(0)
+		assert.NoError(t, err)
+		assert.Equal(t, int8(-1), signed)
+	})
+
+	t.Run("DataViewRanges", func(t *testing.T) {
+		buffer := arrayFuncs.NewArrayBuffer(8)
+
+		view, err := arrayFuncs.NewDataView(buffer, 2, 4)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, view.ByteLength())
+
+		assert.NoError(t, view.SetUint32(0, 0x01020304))
+		assert.Equal(t, []byte{0, 0, 1, 2, 3, 4, 0, 0}, buffer.Bytes())
+
+		_, err = view.GetUint32(1)
+		assert.ErrorIs(t, err, arrayFuncs.ErrOutOfRange)
+		assert.ErrorIs(t, view.SetUint8(-1, 0), arrayFuncs.ErrOutOfRange)
+
+		_, err = arrayFuncs.NewDataView(buffer, 4, 5)
+		assert.ErrorIs(t, err, arrayFuncs.ErrOutOfRange)
+	})
+
+	t.Run("ArrayBufferSlice", func(t *testing.T) {
+		buffer := arrayFuncs.NewArrayBuffer(4)
+		copy(buffer.Bytes(), []byte{1, 2, 3, 4})
+
+		sliced := buffer.Slice(1, -1)
+		assert.Equal(t, []byte{2, 3}, sliced.Bytes())
+
+		sliced.Bytes()[0] = 20
+		assert.Equal(t, byte(2), buffer.Bytes()[1])
+	})
+}
@@ -20,16 +20,18 @@ func AnyToString(value any) (converted string) {
 	}
 
 	switch typeOf.Kind() {
-	case reflect.Int:
-		converted = strconv.FormatInt(int64(valueOf.Interface().(int)), 10)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		converted = strconv.FormatInt(valueOf.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		converted = strconv.FormatUint(valueOf.Uint(), 10)
 	case reflect.Bool:
-		converted = strconv.FormatBool(valueOf.Interface().(bool))
+		converted = strconv.FormatBool(valueOf.Bool())
 	case reflect.Float32:
-		converted = strconv.FormatFloat(float64(valueOf.Interface().(float32)), 'f', -1, 32)
+		converted = strconv.FormatFloat(valueOf.Float(), 'f', -1, 32)
 	case reflect.Float64:
-		converted = strconv.FormatFloat(valueOf.Interface().(float64), 'f', -1, 64)
+		converted = strconv.FormatFloat(valueOf.Float(), 'f', -1, 64)
 	case reflect.String:
-		converted = valueOf.Interface().(string)
+		converted = valueOf.String()
 	case reflect.Struct:
 		temp := reflect.ValueOf(value)
 		toString := temp.MethodByName("ToString")
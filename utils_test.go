@@ -19,6 +19,13 @@ func (t *Temp) ToString() string {
 func TestAnyToString(t *testing.T) {
 	assert.Equal(t, "", arrayFuncs.AnyToString(nil))
 	assert.Equal(t, "10", arrayFuncs.AnyToString(10))
+	assert.Equal(t, "-9223372036854775808", arrayFuncs.AnyToString(int64(-9223372036854775808)))
+	assert.Equal(t, "255", arrayFuncs.AnyToString(uint8(255)))
+	assert.Equal(t, "0.1", arrayFuncs.AnyToString(float32(0.1)))
+
+	type flag bool
+
+	assert.Equal(t, "true", arrayFuncs.AnyToString(flag(true)))
 	assert.Equal(t, "true", arrayFuncs.AnyToString(true))
 	assert.Equal(t, "10.5", arrayFuncs.AnyToString(10.5))
 	assert.Equal(t, "temp", arrayFuncs.AnyToString("temp"))
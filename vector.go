@@ -0,0 +1,318 @@
+package arrayfuncs
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrZeroVector is returned when a vector with norm 0 can't be normalized
+var ErrZeroVector = errors.New("arrayfuncs: vector norm is zero")
+
+// Float is the constraint of the floating point types
+type Float interface {
+	~float32 | ~float64
+}
+
+type vectorOp int
+
+const (
+	vectorAdd vectorOp = iota
+	vectorSub
+	vectorMul
+	vectorDiv
+)
+
+// checkLengths return ErrLengthMismatch when the vectors have different lengths
+func checkLengths[T Number](a, b Array[T]) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("%w: %d and %d", ErrLengthMismatch, len(a), len(b))
+	}
+
+	return nil
+}
+
+// elementwise write on dst the operation between a and b, 4 elements for loop
+func elementwise[T Number](dst, a, b Array[T], op vectorOp) {
+	var (
+		n = len(dst)
+		i = 0
+	)
+
+	// The switch is out of the loops so each loop has only the operation
+	switch op {
+	case vectorAdd:
+		for ; i+4 <= n; i += 4 {
+			dst[i], dst[i+1], dst[i+2], dst[i+3] = a[i]+b[i], a[i+1]+b[i+1], a[i+2]+b[i+2], a[i+3]+b[i+3]
+		}
+
+		for ; i < n; i++ {
+			dst[i] = a[i] + b[i]
+		}
+	case vectorSub:
+		for ; i+4 <= n; i += 4 {
+			dst[i], dst[i+1], dst[i+2], dst[i+3] = a[i]-b[i], a[i+1]-b[i+1], a[i+2]-b[i+2], a[i+3]-b[i+3]
+		}
+
+		for ; i < n; i++ {
+			dst[i] = a[i] - b[i]
+		}
+	case vectorMul:
+		for ; i+4 <= n; i += 4 {
+			dst[i], dst[i+1], dst[i+2], dst[i+3] = a[i]*b[i], a[i+1]*b[i+1], a[i+2]*b[i+2], a[i+3]*b[i+3]
+		}
+
+		for ; i < n; i++ {
+			dst[i] = a[i] * b[i]
+		}
+	case vectorDiv:
+		for ; i+4 <= n; i += 4 {
+			dst[i], dst[i+1], dst[i+2], dst[i+3] = a[i]/b[i], a[i+1]/b[i+1], a[i+2]/b[i+2], a[i+3]/b[i+3]
+		}
+
+		for ; i < n; i++ {
+			dst[i] = a[i] / b[i]
+		}
+	}
+}
+
+// vectorResult allocate the result and apply the operation
+func vectorResult[T Number](a, b Array[T], op vectorOp) (res Array[T], err error) {
+	if err = checkLengths(a, b); err != nil {
+		return
+	}
+
+	res = make(Array[T], len(a))
+	elementwise(res, a, b, op)
+
+	return
+}
+
+// vectorInPlace apply the operation writing on a
+func vectorInPlace[T Number](a, b Array[T], op vectorOp) (err error) {
+	if err = checkLengths(a, b); err != nil {
+		return
+	}
+
+	elementwise(a, a, b, op)
+
+	return
+}
+
+/*
+Add return the sum of each pair of elements.
+Add, Sub, Mul and Div return ErrLengthMismatch if the Arrays have different lengths
+
+	Add(Array[int]{1, 2}, Array[int]{10, 20}) // {11, 22}
+*/
+func Add[T Number](a, b Array[T]) (res Array[T], err error) {
+	return vectorResult(a, b, vectorAdd)
+}
+
+// Sub return the difference of each pair of elements
+func Sub[T Number](a, b Array[T]) (res Array[T], err error) {
+	return vectorResult(a, b, vectorSub)
+}
+
+// Mul return the product of each pair of elements
+func Mul[T Number](a, b Array[T]) (res Array[T], err error) {
+	return vectorResult(a, b, vectorMul)
+}
+
+// Div return the quotient of each pair of elements. Like the Go division, a integer division by zero panics
+func Div[T Number](a, b Array[T]) (res Array[T], err error) {
+	return vectorResult(a, b, vectorDiv)
+}
+
+// AddInPlace add b to a, without allocating
+func AddInPlace[T Number](a, b Array[T]) error {
+	return vectorInPlace(a, b, vectorAdd)
+}
+
+// SubInPlace subtract b from a, without allocating
+func SubInPlace[T Number](a, b Array[T]) error {
+	return vectorInPlace(a, b, vectorSub)
+}
+
+// MulInPlace multiply a by b, without allocating
+func MulInPlace[T Number](a, b Array[T]) error {
+	return vectorInPlace(a, b, vectorMul)
+}
+
+// DivInPlace divide a by b, without allocating
+func DivInPlace[T Number](a, b Array[T]) error {
+	return vectorInPlace(a, b, vectorDiv)
+}
+
+// Scale return each element multiplied by the factor
+func Scale[T Number](a Array[T], factor T) (res Array[T]) {
+	res = make(Array[T], len(a))
+	copy(res, a)
+
+	ScaleInPlace(res, factor)
+
+	return
+}
+
+// ScaleInPlace multiply each element of a by the factor, without allocating
+func ScaleInPlace[T Number](a Array[T], factor T) {
+	var (
+		n = len(a)
+		i = 0
+	)
+
+	for ; i+4 <= n; i += 4 {
+		a[i], a[i+1], a[i+2], a[i+3] = a[i]*factor, a[i+1]*factor, a[i+2]*factor, a[i+3]*factor
+	}
+
+	for ; i < n; i++ {
+		a[i] *= factor
+	}
+}
+
+// Dot return the sum of the products of each pair of elements
+func Dot[T Number](a, b Array[T]) (res T, err error) {
+	if err = checkLengths(a, b); err != nil {
+		return
+	}
+
+	var (
+		n              = len(a)
+		i              = 0
+		s0, s1, s2, s3 T
+	)
+
+	// Four accumulators so the sums don't wait for each other
+	for ; i+4 <= n; i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+
+	for ; i < n; i++ {
+		s0 += a[i] * b[i]
+	}
+
+	return s0 + s1 + s2 + s3, nil
+}
+
+// maxAbs return the greatest absolute value of the vector, used to scale it so the squares don't overflow
+func maxAbs[T Number](a Array[T]) (res float64) {
+	for _, v := range a {
+		res = math.Max(res, math.Abs(float64(v)))
+	}
+
+	return
+}
+
+// Norm return the euclidean length of the vector. The elements are scaled by the greatest one like math.Hypot,
+// so big values don't overflow to +Inf
+func Norm[T Number](a Array[T]) float64 {
+	var (
+		n              = len(a)
+		i              = 0
+		s0, s1, s2, s3 float64
+		max            = maxAbs(a)
+	)
+
+	if max == 0 || math.IsInf(max, 1) {
+		return max
+	}
+
+	for ; i+4 <= n; i += 4 {
+		x0, x1, x2, x3 := float64(a[i])/max, float64(a[i+1])/max, float64(a[i+2])/max, float64(a[i+3])/max
+
+		s0 += x0 * x0
+		s1 += x1 * x1
+		s2 += x2 * x2
+		s3 += x3 * x3
+	}
+
+	for ; i < n; i++ {
+		x := float64(a[i]) / max
+		s0 += x * x
+	}
+
+	return max * math.Sqrt(s0+s1+s2+s3)
+}
+
+// Normalize return the vector divided by its Norm, so it has length 1. It return ErrZeroVector if the norm is 0
+func Normalize[T Number](a Array[T]) (res Array[float64], err error) {
+	res = make(Array[float64], len(a))
+	for i, v := range a {
+		res[i] = float64(v)
+	}
+
+	if err = NormalizeInPlace(res); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// NormalizeInPlace divide the vector by its Norm, without allocating
+func NormalizeInPlace[T Float](a Array[T]) error {
+	max := maxAbs(a)
+	if max == 0 {
+		return ErrZeroVector
+	}
+
+	// Divide by the greatest element first, so neither the norm nor its inverse overflow
+	for i := range a {
+		a[i] /= T(max)
+	}
+
+	ScaleInPlace(a, T(1/Norm(a)))
+
+	return nil
+}
+
+// CosineSimilarity return the cosine of the angle between the vectors, from -1 to 1
+func CosineSimilarity[T Number](a, b Array[T]) (res float64, err error) {
+	if err = checkLengths(a, b); err != nil {
+		return
+	}
+
+	maxA, maxB := maxAbs(a), maxAbs(b)
+	if maxA == 0 || maxB == 0 {
+		return 0, ErrZeroVector
+	}
+
+	var dot, normA, normB float64
+
+	// The cosine doesn't change when the vectors are scaled, so they are divided by their greatest element to not overflow
+	for i := range a {
+		x, y := float64(a[i])/maxA, float64(b[i])/maxB
+
+		dot += x * y
+		normA += x * x
+		normB += y * y
+	}
+
+	return dot / math.Sqrt(normA*normB), nil
+}
+
+// ArgMax return the index of the first greatest element, or -1 if the Array is empty
+func ArgMax[T Number](a Array[T]) (index int) {
+	return argExtreme(a, func(x, y T) bool { return x > y })
+}
+
+// ArgMin return the index of the first lowest element, or -1 if the Array is empty
+func ArgMin[T Number](a Array[T]) (index int) {
+	return argExtreme(a, func(x, y T) bool { return x < y })
+}
+
+func argExtreme[T Number](a Array[T], better func(x, y T) bool) (index int) {
+	if len(a) == 0 {
+		return -1
+	}
+
+	for i := 1; i < len(a); i++ {
+		if better(a[i], a[index]) {
+			index = i
+		}
+	}
+
+	return
+}
@@ -0,0 +1,137 @@
+package arrayfuncs_test
+
+import (
+	"math"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVector(t *testing.T) {
+	a := arrayFuncs.Array[int]{1, 2, 3, 4, 5, 6}
+	b := arrayFuncs.Array[int]{6, 5, 4, 3, 2, 1}
+
+	t.Run("ElementWise", func(t *testing.T) {
+		res, err := arrayFuncs.Add(a, b)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{7, 7, 7, 7, 7, 7}, res)
+
+		res, err = arrayFuncs.Sub(a, b)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{-5, -3, -1, 1, 3, 5}, res)
+
+		res, err = arrayFuncs.Mul(a, b)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{6, 10, 12, 12, 10, 6}, res)
+
+		res, err = arrayFuncs.Div(b, a)
+		assert.NoError(t, err)
+		assert.Equal(t, arrayFuncs.Array[int]{6, 2, 1, 0, 0, 0}, res)
+
+		assert.Equal(t, arrayFuncs.Array[int]{1, 2, 3, 4, 5, 6}, a)
+	})
+
+	t.Run("LengthMismatch", func(t *testing.T) {
+		_, err := arrayFuncs.Add(a, b[:5])
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+
+		_, err = arrayFuncs.Dot(a, b[:1])
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+
+		_, err = arrayFuncs.CosineSimilarity(a, b[:2])
+		assert.ErrorIs(t, err, arrayFuncs.ErrLengthMismatch)
+
+		c := arrayFuncs.Array[int]{1, 2}
+		assert.ErrorIs(t, arrayFuncs.MulInPlace(c, b), arrayFuncs.ErrLengthMismatch)
+		assert.Equal(t, arrayFuncs.Array[int]{1, 2}, c)
+	})
+
+	t.Run("InPlace", func(t *testing.T) {
+		c := arrayFuncs.Array[float64]{1, 2, 3, 4, 5}
+
+		assert.NoError(t, arrayFuncs.AddInPlace(c, arrayFuncs.Array[float64]{1, 1, 1, 1, 1}))
+		assert.Equal(t, arrayFuncs.Array[float64]{2, 3, 4, 5, 6}, c)
+
+		assert.NoError(t, arrayFuncs.SubInPlace(c, arrayFuncs.Array[float64]{2, 2, 2, 2, 2}))
+		assert.NoError(t, arrayFuncs.MulInPlace(c, arrayFuncs.Array[float64]{2, 2, 2, 2, 2}))
+		assert.NoError(t, arrayFuncs.DivInPlace(c, arrayFuncs.Array[float64]{4, 4, 4, 4, 4}))
+		assert.Equal(t, arrayFuncs.Array[float64]{0, 0.5, 1, 1.5, 2}, c)
+
+		arrayFuncs.ScaleInPlace(c, 2)
+		assert.Equal(t, arrayFuncs.Array[float64]{0, 1, 2, 3, 4}, c)
+	})
+
+	t.Run("Scale", func(t *testing.T) {
+		assert.Equal(t, arrayFuncs.Array[int]{3, 6, 9, 12, 15, 18}, arrayFuncs.Scale(a, 3))
+		assert.Equal(t, arrayFuncs.Array[int]{}, arrayFuncs.Scale(arrayFuncs.Array[int]{}, 3))
+	})
+
+	t.Run("DotAndNorm", func(t *testing.T) {
+		dot, err := arrayFuncs.Dot(a, b)
+		assert.NoError(t, err)
+		assert.Equal(t, 56, dot)
+
+		assert.Equal(t, 5.0, arrayFuncs.Norm(arrayFuncs.Array[int]{3, 4}))
+		assert.InDelta(t, math.Sqrt(91), arrayFuncs.Norm(a), 1e-12)
+		assert.Equal(t, 0.0, arrayFuncs.Norm(arrayFuncs.Array[int]{}))
+	})
+
+	t.Run("Normalize", func(t *testing.T) {
+		res, err := arrayFuncs.Normalize(arrayFuncs.Array[int]{3, 4})
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.6, 0.8}, res, 1e-12)
+
+		_, err = arrayFuncs.Normalize(arrayFuncs.Array[int]{0, 0})
+		assert.ErrorIs(t, err, arrayFuncs.ErrZeroVector)
+
+		c := arrayFuncs.Array[float32]{0, 5, 0, 0, 0}
+		assert.NoError(t, arrayFuncs.NormalizeInPlace(c))
+		assert.Equal(t, arrayFuncs.Array[float32]{0, 1, 0, 0, 0}, c)
+	})
+
+	t.Run("CosineSimilarity", func(t *testing.T) {
+		res, err := arrayFuncs.CosineSimilarity(arrayFuncs.Array[float64]{1, 0}, arrayFuncs.Array[float64]{0, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, res)
+
+		res, err = arrayFuncs.CosineSimilarity(a, arrayFuncs.Scale(a, 2))
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0, res, 1e-12)
+
+		_, err = arrayFuncs.CosineSimilarity(a, make(arrayFuncs.Array[int], len(a)))
+		assert.ErrorIs(t, err, arrayFuncs.ErrZeroVector)
+	})
+
+	t.Run("BigAndSmallComponents", func(t *testing.T) {
+		big := arrayFuncs.Array[float64]{3e200, 4e200}
+
+		assert.InDelta(t, 1.0, arrayFuncs.Norm(big)/5e200, 1e-12)
+		assert.InDelta(t, 1.0, arrayFuncs.Norm(arrayFuncs.Array[float64]{3e-200, 4e-200})/5e-200, 1e-12)
+		assert.InDelta(t, 1.0, arrayFuncs.Norm(arrayFuncs.Array[float32]{3e30, 4e30})/5e30, 1e-6)
+
+		res, err := arrayFuncs.Normalize(big)
+		assert.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{0.6, 0.8}, res, 1e-12)
+
+		huge := arrayFuncs.Array[float64]{math.MaxFloat64, math.MaxFloat64}
+		assert.NoError(t, arrayFuncs.NormalizeInPlace(huge))
+		assert.InDeltaSlice(t, []float64{math.Sqrt2 / 2, math.Sqrt2 / 2}, huge, 1e-12)
+
+		cos, err := arrayFuncs.CosineSimilarity(big, arrayFuncs.Array[float64]{4e200, -3e200})
+		assert.NoError(t, err)
+		assert.InDelta(t, 0.0, cos, 1e-12)
+
+		cos, err = arrayFuncs.CosineSimilarity(big, arrayFuncs.Array[float64]{3e-200, 4e-200})
+		assert.NoError(t, err)
+		assert.InDelta(t, 1.0, cos, 1e-12)
+	})
+
+	t.Run("ArgMaxAndArgMin", func(t *testing.T) {
+		c := arrayFuncs.Array[float64]{3, 9, 1, 9, 1}
+
+		assert.Equal(t, 1, arrayFuncs.ArgMax(c))
+		assert.Equal(t, 2, arrayFuncs.ArgMin(c))
+		assert.Equal(t, -1, arrayFuncs.ArgMax(arrayFuncs.Array[float64]{}))
+	})
+}
@@ -0,0 +1,485 @@
+package arrayfuncs
+
+import (
+	"slices"
+	"sort"
+)
+
+/*
+FilterView is a live view with the source elements that satisfy the predicate.
+It is updated on each change of the source, evaluating the predicate only for the new or updated elements
+
+	users := NewObservableArray(list...)
+	active := NewFilterView[User](users, func(v *User) bool { return v.Active })
+
+	users.Push(User{Active: true}) // active.Len() is increased
+
+➡ The predicate don't receive the index because it changes when the source is modified
+*/
+type FilterView[T comparable] struct {
+	observers[T]
+
+	predicate   func(v *T) bool
+	matches     []bool
+	items       Array[T]
+	unsubscribe func()
+}
+
+// NewFilterView create a FilterView over the source
+func NewFilterView[T comparable](source Observable[T], predicate func(v *T) bool) *FilterView[T] {
+	view := &FilterView[T]{predicate: predicate}
+
+	view.insert(0, source.Snapshot())
+	view.unsubscribe = source.Subscribe(view.apply)
+
+	return view
+}
+
+// Len return the elements count
+func (f *FilterView[T]) Len() int {
+	return len(f.items)
+}
+
+// At return an element based on index, like Array.At
+func (f *FilterView[T]) At(index int) (res *T) {
+	if v := f.items.At(index); v != nil {
+		value := *v
+		res = &value
+	}
+
+	return
+}
+
+// Snapshot return a copy of the current elements
+func (f *FilterView[T]) Snapshot() Array[T] {
+	return AnyToArrayKind(f.items)
+}
+
+// Close stop following the source changes
+func (f *FilterView[T]) Close() {
+	f.unsubscribe()
+}
+
+// viewIndex return the position on the view of the source element at index
+func (f *FilterView[T]) viewIndex(index int) (res int) {
+	for i := 0; i < index; i++ {
+		if f.matches[i] {
+			res++
+		}
+	}
+
+	return
+}
+
+func (f *FilterView[T]) insert(index int, values Array[T]) (event *ChangeEvent[T]) {
+	var (
+		flags   = make([]bool, len(values))
+		matched = Array[T]{}
+	)
+
+	for i := range values {
+		flags[i] = f.predicate(&values[i])
+
+		if flags[i] {
+			matched = append(matched, values[i])
+		}
+	}
+
+	viewIndex := f.viewIndex(index)
+	f.matches = append(f.matches[:index:index], append(flags, f.matches[index:]...)...)
+
+	if len(matched) > 0 {
+		f.items.Splice(viewIndex, 0, matched...)
+		event = &ChangeEvent[T]{Kind: ChangeInsert, Index: viewIndex, Values: matched}
+	}
+
+	return
+}
+
+func (f *FilterView[T]) remove(index, count int) (event *ChangeEvent[T]) {
+	var (
+		viewIndex = f.viewIndex(index)
+		viewCount = 0
+	)
+
+	for i := index; i < index+count; i++ {
+		if f.matches[i] {
+			viewCount++
+		}
+	}
+
+	f.matches = append(f.matches[:index:index], f.matches[index+count:]...)
+
+	if viewCount > 0 {
+		removed := f.items.Splice(viewIndex, viewCount)
+		event = &ChangeEvent[T]{Kind: ChangeRemove, Index: viewIndex, Values: removed}
+	}
+
+	return
+}
+
+func (f *FilterView[T]) apply(events []ChangeEvent[T]) {
+	changes := []ChangeEvent[T]{}
+
+	add := func(event *ChangeEvent[T]) {
+		if event != nil {
+			changes = append(changes, *event)
+		}
+	}
+
+	for _, e := range events {
+		switch e.Kind {
+		case ChangeInsert:
+			add(f.insert(e.Index, e.Values))
+		case ChangeRemove:
+			add(f.remove(e.Index, len(e.Values)))
+		case ChangeUpdate:
+			for j, index := range e.Indexes {
+				value := e.Values[j]
+				matches := f.predicate(&value)
+
+				switch {
+				case matches && f.matches[index]:
+					viewIndex := f.viewIndex(index)
+					old := f.items[viewIndex]
+					f.items[viewIndex] = value
+
+					add(&ChangeEvent[T]{
+						Kind:      ChangeUpdate,
+						Indexes:   []int{viewIndex},
+						Values:    Array[T]{value},
+						OldValues: Array[T]{old},
+					})
+				case matches:
+					f.matches[index] = true
+					viewIndex := f.viewIndex(index)
+					f.items.Splice(viewIndex, 0, value)
+
+					add(&ChangeEvent[T]{Kind: ChangeInsert, Index: viewIndex, Values: Array[T]{value}})
+				case f.matches[index]:
+					viewIndex := f.viewIndex(index)
+					f.matches[index] = false
+					removed := f.items.Splice(viewIndex, 1)
+
+					add(&ChangeEvent[T]{Kind: ChangeRemove, Index: viewIndex, Values: removed})
+				}
+			}
+		case ChangeSort, ChangeReverse:
+			var (
+				oldViewIndexes = make([]int, len(f.matches))
+				matches        = make([]bool, len(f.matches))
+				positions      = []int{}
+				items          = Array[T]{}
+				count          = 0
+			)
+
+			for i := range f.matches {
+				oldViewIndexes[i] = count
+
+				if f.matches[i] {
+					count++
+				}
+			}
+
+			for i, old := range e.Indexes {
+				matches[i] = f.matches[old]
+
+				if matches[i] {
+					positions = append(positions, oldViewIndexes[old])
+					items = append(items, f.items[oldViewIndexes[old]])
+				}
+			}
+
+			f.matches = matches
+			f.items = items
+
+			add(&ChangeEvent[T]{Kind: e.Kind, Indexes: positions})
+		}
+	}
+
+	if len(changes) > 0 {
+		f.notify(changes)
+	}
+}
+
+/*
+MapView is a live view with the source elements converted by the mapper.
+The mapper is called only for the new or updated elements of the source
+
+	names := MapTo[User](users, func(v User) string { return v.Name })
+*/
+type MapView[T, U comparable] struct {
+	observers[U]
+
+	mapper      func(v T) U
+	items       Array[U]
+	unsubscribe func()
+}
+
+// MapTo create a MapView over the source
+func MapTo[T, U comparable](source Observable[T], mapper func(v T) U) *MapView[T, U] {
+	view := &MapView[T, U]{mapper: mapper}
+
+	view.items = view.mapAll(source.Snapshot())
+	view.unsubscribe = source.Subscribe(view.apply)
+
+	return view
+}
+
+// Len return the elements count
+func (m *MapView[T, U]) Len() int {
+	return len(m.items)
+}
+
+// At return an element based on index, like Array.At
+func (m *MapView[T, U]) At(index int) (res *U) {
+	if v := m.items.At(index); v != nil {
+		value := *v
+		res = &value
+	}
+
+	return
+}
+
+// Snapshot return a copy of the current elements
+func (m *MapView[T, U]) Snapshot() Array[U] {
+	return AnyToArrayKind(m.items)
+}
+
+// Close stop following the source changes
+func (m *MapView[T, U]) Close() {
+	m.unsubscribe()
+}
+
+func (m *MapView[T, U]) mapAll(values Array[T]) (res Array[U]) {
+	res = make(Array[U], len(values))
+
+	for i := range values {
+		res[i] = m.mapper(values[i])
+	}
+
+	return
+}
+
+func (m *MapView[T, U]) apply(events []ChangeEvent[T]) {
+	changes := make([]ChangeEvent[U], 0, len(events))
+
+	for _, e := range events {
+		change := ChangeEvent[U]{Kind: e.Kind, Index: e.Index, Indexes: e.Indexes}
+
+		switch e.Kind {
+		case ChangeInsert:
+			change.Values = m.mapAll(e.Values)
+			m.items.Splice(e.Index, 0, change.Values...)
+		case ChangeRemove:
+			change.Values = m.items.Splice(e.Index, len(e.Values))
+		case ChangeUpdate:
+			change.Values = m.mapAll(e.Values)
+			change.OldValues = make(Array[U], len(e.Indexes))
+
+			for j, index := range e.Indexes {
+				change.OldValues[j] = m.items[index]
+				m.items[index] = change.Values[j]
+			}
+		case ChangeSort, ChangeReverse:
+			items := make(Array[U], len(e.Indexes))
+
+			for i, old := range e.Indexes {
+				items[i] = m.items[old]
+			}
+
+			m.items = items
+		}
+
+		changes = append(changes, change)
+	}
+
+	if len(changes) > 0 {
+		m.notify(changes)
+	}
+}
+
+type sortedEntry[T comparable] struct {
+	value T
+	id    int
+}
+
+/*
+SortedView is a live view with the source elements always sorted by the less function.
+Each change of the source is applied with binary search, without sorting everything again
+
+	sorted := NewSortedView[User](active, func(a, b User) bool { return a.Name < b.Name })
+
+➡ Equal elements keep the order they were added to the view
+*/
+type SortedView[T comparable] struct {
+	observers[T]
+
+	less        func(a, b T) bool
+	entries     []sortedEntry[T]
+	ids         []int
+	nextID      int
+	unsubscribe func()
+}
+
+// NewSortedView create a SortedView over the source
+func NewSortedView[T comparable](source Observable[T], less func(a, b T) bool) *SortedView[T] {
+	view := &SortedView[T]{less: less}
+
+	for _, v := range source.Snapshot() {
+		id := view.newID()
+		view.ids = append(view.ids, id)
+		view.add(v, id)
+	}
+
+	view.unsubscribe = source.Subscribe(view.apply)
+
+	return view
+}
+
+// Len return the elements count
+func (s *SortedView[T]) Len() int {
+	return len(s.entries)
+}
+
+// At return an element based on index, like Array.At
+func (s *SortedView[T]) At(index int) (res *T) {
+	if index < 0 {
+		index += len(s.entries)
+	}
+
+	if index >= 0 && index < len(s.entries) {
+		value := s.entries[index].value
+		res = &value
+	}
+
+	return
+}
+
+// Snapshot return a copy of the current elements
+func (s *SortedView[T]) Snapshot() (res Array[T]) {
+	res = make(Array[T], len(s.entries))
+
+	for i := range s.entries {
+		res[i] = s.entries[i].value
+	}
+
+	return
+}
+
+// Close stop following the source changes
+func (s *SortedView[T]) Close() {
+	s.unsubscribe()
+}
+
+func (s *SortedView[T]) newID() int {
+	s.nextID++
+	return s.nextID
+}
+
+// add insert the value after all the equal ones and return its position
+func (s *SortedView[T]) add(value T, id int) int {
+	index := sort.Search(len(s.entries), func(i int) bool {
+		return s.less(value, s.entries[i].value)
+	})
+
+	s.entries = append(s.entries, sortedEntry[T]{})
+	copy(s.entries[index+1:], s.entries[index:])
+	s.entries[index] = sortedEntry[T]{value, id}
+
+	return index
+}
+
+// delete remove the entry with the id and return its position, or -1 if the view doesn't have it
+func (s *SortedView[T]) delete(value T, id int) int {
+	index := sort.Search(len(s.entries), func(i int) bool {
+		return !s.less(s.entries[i].value, value)
+	})
+
+	for index < len(s.entries) && s.entries[index].id != id {
+		index++
+	}
+
+	// The less function changed its order, so the entry can be anywhere
+	if index == len(s.entries) {
+		index = slices.IndexFunc(s.entries, func(e sortedEntry[T]) bool { return e.id == id })
+		if index < 0 {
+			return -1
+		}
+	}
+
+	s.entries = append(s.entries[:index], s.entries[index+1:]...)
+
+	return index
+}
+
+func (s *SortedView[T]) apply(events []ChangeEvent[T]) {
+	changes := []ChangeEvent[T]{}
+
+	for _, e := range events {
+		switch e.Kind {
+		case ChangeInsert:
+			ids := make([]int, len(e.Values))
+
+			for j, v := range e.Values {
+				ids[j] = s.newID()
+				index := s.add(v, ids[j])
+
+				changes = append(changes, ChangeEvent[T]{Kind: ChangeInsert, Index: index, Values: Array[T]{v}})
+			}
+
+			s.ids = append(s.ids[:e.Index:e.Index], append(ids, s.ids[e.Index:]...)...)
+		case ChangeRemove:
+			for j, v := range e.Values {
+				index := s.delete(v, s.ids[e.Index+j])
+				if index < 0 {
+					continue
+				}
+
+				changes = append(changes, ChangeEvent[T]{Kind: ChangeRemove, Index: index, Values: Array[T]{v}})
+			}
+
+			s.ids = append(s.ids[:e.Index:e.Index], s.ids[e.Index+len(e.Values):]...)
+		case ChangeUpdate:
+			for j, sourceIndex := range e.Indexes {
+				var (
+					old   = e.OldValues[j]
+					value = e.Values[j]
+					id    = s.ids[sourceIndex]
+				)
+
+				removedAt := s.delete(old, id)
+				addedAt := s.add(value, id)
+
+				if removedAt < 0 {
+					changes = append(changes, ChangeEvent[T]{Kind: ChangeInsert, Index: addedAt, Values: Array[T]{value}})
+				} else if removedAt == addedAt {
+					changes = append(changes, ChangeEvent[T]{
+						Kind:      ChangeUpdate,
+						Indexes:   []int{addedAt},
+						Values:    Array[T]{value},
+						OldValues: Array[T]{old},
+					})
+				} else {
+					changes = append(changes,
+						ChangeEvent[T]{Kind: ChangeRemove, Index: removedAt, Values: Array[T]{old}},
+						ChangeEvent[T]{Kind: ChangeInsert, Index: addedAt, Values: Array[T]{value}},
+					)
+				}
+			}
+		case ChangeSort, ChangeReverse:
+			// The view order doesn't depend on the source order, only the ids need to follow it
+			ids := make([]int, len(e.Indexes))
+
+			for i, old := range e.Indexes {
+				ids[i] = s.ids[old]
+			}
+
+			s.ids = ids
+		}
+	}
+
+	if len(changes) > 0 {
+		s.notify(changes)
+	}
+}
@@ -0,0 +1,197 @@
+package arrayfuncs_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	arrayFuncs "github.com/izacgaldino23/array-funcs"
+	"github.com/stretchr/testify/assert"
+)
+
+func snapshotOf(view arrayFuncs.Observable[int]) []int {
+	snapshot := view.Snapshot()
+
+	return snapshot.ToOriginalKind()
+}
+
+func TestViews(t *testing.T) {
+	isEven := func(v *int) bool {
+		return *v%2 == 0
+	}
+
+	double := func(v int) int {
+		return v * 2
+	}
+
+	less := func(a, b int) bool {
+		return a < b
+	}
+
+	t.Run("FilterView", func(t *testing.T) {
+		source := arrayFuncs.NewObservableArray(1, 2, 3, 4)
+		view := arrayFuncs.NewFilterView[int](source, isEven)
+
+		assert.Equal(t, arrayFuncs.Array[int]{2, 4}, view.Snapshot())
+
+		source.Push(6, 7)
+		source.Shift()
+		source.Fill(8, 0, 0)
+
+		assert.Equal(t, arrayFuncs.Array[int]{8, 4, 6}, view.Snapshot())
+
+		view.Close()
+		source.Push(10)
+
+		assert.Equal(t, 3, view.Len())
+	})
+
+	t.Run("MapTo", func(t *testing.T) {
+		source := arrayFuncs.NewObservableArray(1, 2, 3)
+		view := arrayFuncs.MapTo[int](source, double)
+
+		source.Unshift(0)
+		source.Reverse()
+		source.Splice(1, 1)
+
+		assert.Equal(t, arrayFuncs.Array[int]{6, 2, 0}, view.Snapshot())
+		assert.Equal(t, 0, *view.At(-1))
+	})
+
+	t.Run("SortedView", func(t *testing.T) {
+		source := arrayFuncs.NewObservableArray(5, 1, 3)
+		view := arrayFuncs.NewSortedView[int](source, less)
+
+		assert.Equal(t, arrayFuncs.Array[int]{1, 3, 5}, view.Snapshot())
+
+		events := []arrayFuncs.ChangeEvent[int]{}
+		view.Subscribe(func(e []arrayFuncs.ChangeEvent[int]) {
+			events = append(events, e...)
+		})
+
+		source.Push(2)
+
+		assert.Equal(t, arrayFuncs.Array[int]{1, 2, 3, 5}, view.Snapshot())
+		assert.Equal(t, []arrayFuncs.ChangeEvent[int]{
+			{Kind: arrayFuncs.ChangeInsert, Index: 1, Values: arrayFuncs.Array[int]{2}},
+		}, events)
+	})
+
+	t.Run("SortedViewLessChanged", func(t *testing.T) {
+		var (
+			reversed = false
+			source   = arrayFuncs.NewObservableArray(1, 2, 3)
+			view     = arrayFuncs.NewSortedView[int](source, func(a, b int) bool { return (a < b) != reversed })
+		)
+
+		events := []arrayFuncs.ChangeEvent[int]{}
+		view.Subscribe(func(e []arrayFuncs.ChangeEvent[int]) {
+			events = append(events, e...)
+		})
+
+		reversed = true
+		source.Shift()
+
+		assert.Equal(t, arrayFuncs.Array[int]{2, 3}, view.Snapshot())
+		assert.Equal(t, []arrayFuncs.ChangeEvent[int]{
+			{Kind: arrayFuncs.ChangeRemove, Index: 0, Values: arrayFuncs.Array[int]{1}},
+		}, events)
+	})
+
+	t.Run("SortedActiveUsers", func(t *testing.T) {
+		type user struct {
+			name   string
+			active bool
+		}
+
+		var (
+			users  = arrayFuncs.NewObservableArray(user{"carl", true}, user{"ann", false}, user{"bob", true})
+			active = arrayFuncs.NewFilterView[user](users, func(v *user) bool { return v.active })
+			sorted = arrayFuncs.NewSortedView[user](active, func(a, b user) bool { return a.name < b.name })
+		)
+
+		names := func() (res []string) {
+			for _, u := range sorted.Snapshot() {
+				res = append(res, u.name)
+			}
+
+			return
+		}
+
+		assert.Equal(t, []string{"bob", "carl"}, names())
+
+		users.Push(user{"alice", true})
+		users.Splice(0, 1)
+		users.Map(func(v *user, i int) {
+			if v.name == "ann" {
+				v.active = true
+			}
+		})
+
+		assert.Equal(t, []string{"alice", "ann", "bob"}, names())
+	})
+
+	t.Run("RandomMutations", func(t *testing.T) {
+		var (
+			random   = rand.New(rand.NewSource(42))
+			source   = arrayFuncs.NewObservableArray[int]()
+			filtered = arrayFuncs.NewFilterView[int](source, isEven)
+			mapped   = arrayFuncs.MapTo[int](filtered, double)
+			sorted   = arrayFuncs.NewSortedView[int](mapped, less)
+		)
+
+		for i := 0; i < 2000; i++ {
+			length := source.Len()
+
+			switch random.Intn(9) {
+			case 0:
+				source.Push(random.Intn(100), random.Intn(100))
+			case 1:
+				source.Pop()
+			case 2:
+				source.Shift()
+			case 3:
+				source.Unshift(random.Intn(100))
+			case 4:
+				source.Splice(random.Intn(length+1)-length/2, random.Intn(3), random.Intn(100), random.Intn(100))
+			case 5:
+				if length > 0 {
+					source.Fill(random.Intn(100), random.Intn(length), random.Intn(length))
+				}
+			case 6:
+				source.Map(func(v *int, i int) {
+					if random.Intn(4) == 0 {
+						*v = random.Intn(100)
+					}
+				})
+			case 7:
+				source.Sort(func(index1, index2 int) bool {
+					return *source.At(index1)%10 < *source.At(index2)%10
+				})
+			case 8:
+				source.Transaction(func(o *arrayFuncs.ObservableArray[int]) {
+					o.Reverse()
+					o.Push(random.Intn(100))
+					o.Pop()
+					o.Pop()
+				})
+			}
+
+			// Full recomputation
+			snapshot := source.Snapshot()
+			expectedFiltered := snapshot.Filter(func(v *int, i int) bool { return isEven(v) })
+			expectedMapped := arrayFuncs.AnyToArrayKind(expectedFiltered)
+			expectedMapped.Map(func(v *int, i int) { *v = double(*v) })
+			expectedSorted := arrayFuncs.AnyToArrayKind(expectedMapped)
+			sort.Ints(expectedSorted)
+
+			if !assert.Equal(t, len(expectedFiltered), filtered.Len()) {
+				return
+			}
+
+			assert.Equal(t, expectedFiltered.ToOriginalKind(), snapshotOf(filtered))
+			assert.Equal(t, expectedMapped.ToOriginalKind(), snapshotOf(mapped))
+			assert.Equal(t, expectedSorted.ToOriginalKind(), snapshotOf(sorted))
+		}
+	})
+}